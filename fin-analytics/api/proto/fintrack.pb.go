@@ -1,267 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.10
-// 	protoc        v6.33.0
-// source: fintrack.proto
-
-package proto
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type UserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UserRequest) Reset() {
-	*x = UserRequest{}
-	mi := &file_fintrack_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UserRequest) ProtoMessage() {}
-
-func (x *UserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_fintrack_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UserRequest.ProtoReflect.Descriptor instead.
-func (*UserRequest) Descriptor() ([]byte, []int) {
-	return file_fintrack_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *UserRequest) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-type Transaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Transaction) Reset() {
-	*x = Transaction{}
-	mi := &file_fintrack_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Transaction) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Transaction) ProtoMessage() {}
-
-func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_fintrack_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
-func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_fintrack_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *Transaction) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Transaction) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *Transaction) GetAmount() float64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
-func (x *Transaction) GetCategory() string {
-	if x != nil {
-		return x.Category
-	}
-	return ""
-}
-
-func (x *Transaction) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *Transaction) GetCreatedAt() string {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
-}
-
-type UserTransactions struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UserTransactions) Reset() {
-	*x = UserTransactions{}
-	mi := &file_fintrack_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UserTransactions) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UserTransactions) ProtoMessage() {}
-
-func (x *UserTransactions) ProtoReflect() protoreflect.Message {
-	mi := &file_fintrack_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UserTransactions.ProtoReflect.Descriptor instead.
-func (*UserTransactions) Descriptor() ([]byte, []int) {
-	return file_fintrack_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *UserTransactions) GetTransactions() []*Transaction {
-	if x != nil {
-		return x.Transactions
-	}
-	return nil
-}
-
-var File_fintrack_proto protoreflect.FileDescriptor
-
-const file_fintrack_proto_rawDesc = "" +
-	"\n" +
-	"\x0efintrack.proto\x12\vfintrack.v1\"&\n" +
-	"\vUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x9d\x01\n" +
-	"\vTransaction\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
-	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\"P\n" +
-	"\x10UserTransactions\x12<\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x18.fintrack.v1.TransactionR\ftransactions2d\n" +
-	"\x12TransactionService\x12N\n" +
-	"\x13GetUserTransactions\x12\x18.fintrack.v1.UserRequest\x1a\x1d.fintrack.v1.UserTransactionsB\x1fZ\x1dfin-track-app/api/proto;protob\x06proto3"
-
-var (
-	file_fintrack_proto_rawDescOnce sync.Once
-	file_fintrack_proto_rawDescData []byte
-)
-
-func file_fintrack_proto_rawDescGZIP() []byte {
-	file_fintrack_proto_rawDescOnce.Do(func() {
-		file_fintrack_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fintrack_proto_rawDesc), len(file_fintrack_proto_rawDesc)))
-	})
-	return file_fintrack_proto_rawDescData
-}
-
-var file_fintrack_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_fintrack_proto_goTypes = []any{
-	(*UserRequest)(nil),      // 0: fintrack.v1.UserRequest
-	(*Transaction)(nil),      // 1: fintrack.v1.Transaction
-	(*UserTransactions)(nil), // 2: fintrack.v1.UserTransactions
-}
-var file_fintrack_proto_depIdxs = []int32{
-	1, // 0: fintrack.v1.UserTransactions.transactions:type_name -> fintrack.v1.Transaction
-	0, // 1: fintrack.v1.TransactionService.GetUserTransactions:input_type -> fintrack.v1.UserRequest
-	2, // 2: fintrack.v1.TransactionService.GetUserTransactions:output_type -> fintrack.v1.UserTransactions
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
-}
-
-func init() { file_fintrack_proto_init() }
-func file_fintrack_proto_init() {
-	if File_fintrack_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fintrack_proto_rawDesc), len(file_fintrack_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_fintrack_proto_goTypes,
-		DependencyIndexes: file_fintrack_proto_depIdxs,
-		MessageInfos:      file_fintrack_proto_msgTypes,
-	}.Build()
-	File_fintrack_proto = out.File
-	file_fintrack_proto_goTypes = nil
-	file_fintrack_proto_depIdxs = nil
-}
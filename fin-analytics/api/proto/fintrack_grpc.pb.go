@@ -1,121 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.6.0
-// - protoc             v6.33.0
-// source: fintrack.proto
-
-package proto
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	TransactionService_GetUserTransactions_FullMethodName = "/fintrack.v1.TransactionService/GetUserTransactions"
-)
-
-// TransactionServiceClient is the client API for TransactionService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type TransactionServiceClient interface {
-	GetUserTransactions(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserTransactions, error)
-}
-
-type transactionServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewTransactionServiceClient(cc grpc.ClientConnInterface) TransactionServiceClient {
-	return &transactionServiceClient{cc}
-}
-
-func (c *transactionServiceClient) GetUserTransactions(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserTransactions, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UserTransactions)
-	err := c.cc.Invoke(ctx, TransactionService_GetUserTransactions_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// TransactionServiceServer is the server API for TransactionService service.
-// All implementations must embed UnimplementedTransactionServiceServer
-// for forward compatibility.
-type TransactionServiceServer interface {
-	GetUserTransactions(context.Context, *UserRequest) (*UserTransactions, error)
-	mustEmbedUnimplementedTransactionServiceServer()
-}
-
-// UnimplementedTransactionServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedTransactionServiceServer struct{}
-
-func (UnimplementedTransactionServiceServer) GetUserTransactions(context.Context, *UserRequest) (*UserTransactions, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetUserTransactions not implemented")
-}
-func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
-func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
-
-// UnsafeTransactionServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TransactionServiceServer will
-// result in compilation errors.
-type UnsafeTransactionServiceServer interface {
-	mustEmbedUnimplementedTransactionServiceServer()
-}
-
-func RegisterTransactionServiceServer(s grpc.ServiceRegistrar, srv TransactionServiceServer) {
-	// If the following call panics, it indicates UnimplementedTransactionServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&TransactionService_ServiceDesc, srv)
-}
-
-func _TransactionService_GetUserTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TransactionServiceServer).GetUserTransactions(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: TransactionService_GetUserTransactions_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TransactionServiceServer).GetUserTransactions(ctx, req.(*UserRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TransactionService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "fintrack.v1.TransactionService",
-	HandlerType: (*TransactionServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetUserTransactions",
-			Handler:    _TransactionService_GetUserTransactions_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "fintrack.proto",
-}
@@ -2,50 +2,229 @@ package main
 
 import (
 	"context"
-	"fin-analytics/config"
-	"fin-analytics/internal/bootstrap"
+	"crypto/tls"
 	"fin-analytics/internal/cache"
 	"fin-analytics/internal/database"
+	finanalyticsgrpc "fin-analytics/internal/grpc"
 	"fin-analytics/internal/grpcclient"
 	finanalyticshttp "fin-analytics/internal/http"
+	"fin-analytics/internal/metrics"
+	"fin-analytics/internal/migrations"
+	"fin-analytics/internal/repository"
 	"fin-analytics/internal/service"
-	"log"
-	"time"
+	"fin-shared/auth"
+	"fin-shared/bootstrap"
+	"fin-shared/certs"
+	"fin-shared/config"
+	"fin-shared/health"
+	"fin-shared/logging"
+	"fin-shared/tracing"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
 
 	"fin-analytics/internal/kafka"
 )
 
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	flags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
 
-	cfg, err := config.Load("config.yaml")
+	if flags.PrintConfig {
+		if err := config.Print(os.Stdout, flags.Path, config.FinAnalytics); err != nil {
+			logging.Fatal("Invalid config", "error", err)
+		}
+		return
+	}
+
+	if err := run(context.Background(), flags.Path); err != nil {
+		logging.Fatal("fin-analytics stopped with error", "error", err)
+	}
+}
+
+// run wires the components in dependency order. On shutdown readiness
+// fails first, then the consumer finishes and commits the message in
+// flight and the HTTP and gRPC servers drain, and only then are the clients closed.
+func run(ctx context.Context, configPath string) error {
+	cfg, err := config.Load(configPath, config.FinAnalytics)
+	if err != nil {
+		return err
+	}
+
+	logger, err := logging.New(cfg.Logging, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	slog.SetDefault(logger)
+
+	app := bootstrap.New(cfg.Shutdown.Timeout)
+
+	tracer, err := tracing.New(ctx, cfg.Tracing, "fin-analytics")
 	if err != nil {
-		log.Fatalf("load config: %v", err)
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	app.Append(bootstrap.Hook{Name: "tracing", OnStop: tracer.Shutdown})
+
+	var grpcTLS, httpTLS, clientTLS *tls.Config
+	if cfg.TLS.Enabled {
+		certificates, err := certs.New(cfg.TLS)
+		if err != nil {
+			return app.Abort(fmt.Errorf("load tls certificates: %w", err))
+		}
+		app.Append(bootstrap.Hook{
+			Name: "tls certificates",
+			Run:  certificates.Run,
+			OnStop: func(context.Context) error {
+				certificates.Stop()
+				return nil
+			},
+		})
+		grpcTLS, httpTLS = certificates.ServerConfig(cfg.TLS.ClientAuth), certificates.ServerConfig(false)
+		clientTLS = certificates.ClientConfig()
 	}
 
 	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
 	if err != nil {
-		log.Fatalf("connect redis: %v", err)
+		return app.Abort(fmt.Errorf("connect redis: %w", err))
+	}
+	app.Append(bootstrap.Hook{
+		Name: "redis",
+		OnStop: func(context.Context) error {
+			return redisClient.Close()
+		},
+	})
+
+	pgPool, err := database.NewPostgresPool(ctx, cfg.AnalyticsDB)
+	if err != nil {
+		return app.Abort(fmt.Errorf("connect analytics store: %w", err))
+	}
+	app.Append(bootstrap.Hook{
+		Name: "postgres",
+		OnStop: func(context.Context) error {
+			pgPool.Close()
+			return nil
+		},
+	})
+
+	if cfg.AnalyticsDB.AutoMigrate {
+		migrator, err := migrations.New(pgPool, cfg.AnalyticsDB.Schema)
+		if err != nil {
+			return app.Abort(fmt.Errorf("load migrations: %w", err))
+		}
+		app.Append(bootstrap.Hook{Name: "migrations", OnStart: migrator.Up})
 	}
-	defer redisClient.Close()
 
-	cache := cache.New(redisClient, 15*time.Minute)
+	m := metrics.New()
+	store := repository.NewAnalyticsRepository(pgPool, cfg.AnalyticsDB.Schema, m)
+
+	redisCache := cache.New(redisClient, cfg.Cache.TTL, cfg.Cache.StaleTTL, m)
+	var statsCache cache.StatsCache = redisCache
+	if cfg.Cache.Local.MaxEntries > 0 {
+		tiered := cache.NewTiered(redisCache, cfg.Cache.Local, m)
+		app.Append(bootstrap.Hook{
+			Name: "stats cache invalidation",
+			Run:  tiered.Run,
+			OnStop: func(context.Context) error {
+				tiered.Stop()
+				return nil
+			},
+		})
+		statsCache = tiered
+	}
 
-	grpcClient, err := grpcclient.New(cfg.FinAPI.GRPCTarget)
+	grpcClient, err := grpcclient.New(cfg.FinAPI.GRPCTarget, cfg.FinAPI.GRPCEndpoints, cfg.FinAPI.GRPCClient, clientTLS, m)
 	if err != nil {
-		log.Fatalf("grpc client: %v", err)
+		return app.Abort(fmt.Errorf("create grpc client: %w", err))
 	}
+	app.Append(bootstrap.Hook{
+		Name: "fin-api client",
+		OnStop: func(context.Context) error {
+			return grpcClient.Close()
+		},
+	})
 
-	svc := service.New(cache, grpcClient)
+	watcher := config.NewWatcher(configPath, config.FinAnalytics, cfg)
+	updates := cache.NewUpdates(redisClient)
+	svc := service.New(statsCache, updates, store, grpcClient, watcher)
+	app.Append(bootstrap.Hook{
+		Name: "stats updates",
+		Run: func() error {
+			return updates.Run(svc.Deliver)
+		},
+		OnStop: func(context.Context) error {
+			updates.Stop()
+			return nil
+		},
+	})
+	svc.SetSoftTTL(cfg.Cache.SoftTTL)
+	watcher.OnReload(func(cfg *config.Config) {
+		_ = logging.SetLevel(cfg.Logging.Level)
+		redisCache.SetTTL(cfg.Cache.TTL)
+		svc.SetSoftTTL(cfg.Cache.SoftTTL)
+	})
+	app.Append(bootstrap.Hook{
+		Name: "config watcher",
+		Run:  watcher.Run,
+		OnStop: func(context.Context) error {
+			watcher.Stop()
+			return nil
+		},
+	})
 
-	kafkaConsumer, err := kafka.NewConsumer(cfg.Kafka.Brokers, cfg.Kafka.GroupID, []string{cfg.App.KafkaTopic}, svc.ProcessKafkaMessage)
+	kafkaConsumer, err := kafka.NewConsumer(cfg.Kafka.Brokers, cfg.Kafka.GroupID, []string{cfg.App.KafkaTopic}, svc.ProcessKafkaMessage, m)
 	if err != nil {
-		log.Fatalf("kafka consumer: %v", err)
+		return app.Abort(fmt.Errorf("create kafka consumer: %w", err))
 	}
-	defer kafkaConsumer.Close()
+	app.Append(bootstrap.Hook{
+		Name: "kafka consumer",
+		Run: func() error {
+			slog.Info("fin-analytics consumer started")
+			return kafkaConsumer.Start()
+		},
+		OnStop: kafkaConsumer.Stop,
+	})
+
+	ready := health.NewChecker(cfg.Readiness.Timeout)
+	ready.Register("redis", health.Simple(func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	}))
+	ready.Register("postgres", health.Simple(pgPool.Ping))
+	// Stats are served from the store and the stale cache while fin-api is
+	// down, and group membership drops on every rebalance, so neither takes
+	// the instance out of rotation.
+	ready.Register("fin-api", health.Optional(health.Simple(grpcClient.Ping)))
+	ready.Register("kafka", health.Optional(kafkaConsumer.Ping))
+
+	analyticsHTTP := finanalyticshttp.NewServer(svc, ready, httpTLS, m)
+	httpAddr := fmt.Sprintf("%s:%d", cfg.FinAnalytics.HTTPHost, cfg.FinAnalytics.HTTPPort)
+	app.Append(bootstrap.Hook{
+		Name: "http",
+		Run: func() error {
+			slog.Info("fin-analytics HTTP listening", "addr", httpAddr)
+			return analyticsHTTP.Start(httpAddr)
+		},
+		OnStop: analyticsHTTP.Stop,
+	})
+
+	analyticsGRPC := finanalyticsgrpc.NewServer(svc, auth.New(cfg.Auth.APIKeys), grpcTLS, m)
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.FinAnalytics.GRPCHost, cfg.FinAnalytics.GRPCPort)
+	app.Append(bootstrap.Hook{
+		Name: "grpc",
+		Run: func() error {
+			slog.Info("fin-analytics gRPC listening", "addr", grpcAddr)
+			return analyticsGRPC.Start(grpcAddr)
+		},
+		OnStop: analyticsGRPC.Stop,
+	})
 
-	analyticsHTTP := finanalyticshttp.NewServer(svc)
+	app.Append(bootstrap.Hook{
+		Name: "readiness",
+		OnStop: func(ctx context.Context) error {
+			ready.DrainFor(ctx, cfg.Shutdown.DrainDelay)
+			return nil
+		},
+	})
 
-	bootstrap.RunAnalyticsApp(ctx, cancel, analyticsHTTP, kafkaConsumer, cfg)
+	return app.Run(ctx)
 }
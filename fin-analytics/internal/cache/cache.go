@@ -8,5 +8,8 @@ import (
 
 type StatsCache interface {
 	Get(ctx context.Context, userID int) (*domain.FinanceStats, error)
+	// GetStale returns the last stats written for the user, kept for
+	// stale_ttl so they can be served while fin-api is unavailable.
+	GetStale(ctx context.Context, userID int) (*domain.FinanceStats, error)
 	Set(ctx context.Context, stats domain.FinanceStats) error
 }
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"fin-analytics/internal/domain"
+)
+
+const (
+	// statsOverhead and categoryOverhead approximate the memory of a
+	// FinanceStats and of one map entry beyond its key.
+	statsOverhead    = 256
+	categoryOverhead = 48
+)
+
+type lruEntry struct {
+	userID    int
+	stats     domain.FinanceStats
+	size      int64
+	expiresAt time.Time
+}
+
+// pendingFill counts the Redis reads in flight for a user and the writes
+// and invalidations of the user since the first of them started.
+type pendingFill struct {
+	readers    int
+	generation uint64
+}
+
+// lru holds stats by user, evicting the least recently read once either
+// bound is exceeded.
+type lru struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	ttl        time.Duration
+	bytes      int64
+	order      *list.List
+	entries    map[int]*list.Element
+	// fills holds the users with a Redis read in flight only, so it stays
+	// as small as the number of concurrent misses.
+	fills map[int]*pendingFill
+	now   func() time.Time
+}
+
+func newLRU(maxEntries int, maxBytes int64, ttl time.Duration) *lru {
+	return &lru{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[int]*list.Element),
+		fills:      make(map[int]*pendingFill),
+		now:        time.Now,
+	}
+}
+
+func (l *lru) get(userID int) (domain.FinanceStats, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.entries[userID]
+	if !ok {
+		return domain.FinanceStats{}, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !l.now().Before(entry.expiresAt) {
+		l.remove(elem)
+		return domain.FinanceStats{}, false
+	}
+	l.order.MoveToFront(elem)
+	return entry.stats, true
+}
+
+func (l *lru) put(stats domain.FinanceStats) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.invalidateFill(stats.UserID)
+	l.store(stats)
+}
+
+// startFill registers a read of the user's stats from Redis and returns
+// the generation to pass to endFill when it returns.
+func (l *lru) startFill(userID int) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fill, ok := l.fills[userID]
+	if !ok {
+		fill = &pendingFill{}
+		l.fills[userID] = fill
+	}
+	fill.readers++
+	return fill.generation
+}
+
+// endFill keeps stats, which may be nil, read since startFill returned
+// generation, unless the user was put, deleted or cleared in the meantime:
+// the read may then predate that write or invalidation.
+func (l *lru) endFill(userID int, generation uint64, stats *domain.FinanceStats) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fill := l.fills[userID]
+	fill.readers--
+	if fill.readers == 0 {
+		delete(l.fills, userID)
+	}
+	if stats != nil && fill.generation == generation {
+		l.store(*stats)
+	}
+}
+
+func (l *lru) invalidateFill(userID int) {
+	if fill, ok := l.fills[userID]; ok {
+		fill.generation++
+	}
+}
+
+func (l *lru) store(stats domain.FinanceStats) {
+	if elem, ok := l.entries[stats.UserID]; ok {
+		l.remove(elem)
+	}
+	entry := &lruEntry{
+		userID:    stats.UserID,
+		stats:     stats,
+		size:      statsSize(stats),
+		expiresAt: l.now().Add(l.ttl),
+	}
+	if entry.size > l.maxBytes {
+		return
+	}
+	l.entries[stats.UserID] = l.order.PushFront(entry)
+	l.bytes += entry.size
+	for l.order.Len() > l.maxEntries || l.bytes > l.maxBytes {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *lru) delete(userID int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.invalidateFill(userID)
+	if elem, ok := l.entries[userID]; ok {
+		l.remove(elem)
+	}
+}
+
+func (l *lru) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	l.entries = make(map[int]*list.Element)
+	l.bytes = 0
+	for _, fill := range l.fills {
+		fill.generation++
+	}
+}
+
+func (l *lru) remove(elem *list.Element) {
+	entry := l.order.Remove(elem).(*lruEntry)
+	delete(l.entries, entry.userID)
+	l.bytes -= entry.size
+}
+
+func statsSize(stats domain.FinanceStats) int64 {
+	size := int64(statsOverhead)
+	for category := range stats.ExpenseByCategory {
+		size += int64(len(category) + categoryOverhead)
+	}
+	for category := range stats.IncomeByCategory {
+		size += int64(len(category) + categoryOverhead)
+	}
+	return size
+}
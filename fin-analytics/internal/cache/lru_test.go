@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fin-analytics/internal/domain"
+)
+
+func TestLRUEvictsLeastRecentlyRead(t *testing.T) {
+	l := newLRU(2, 1<<20, time.Minute)
+	l.put(domain.FinanceStats{UserID: 1})
+	l.put(domain.FinanceStats{UserID: 2})
+
+	_, ok := l.get(1)
+	assert.True(t, ok)
+	l.put(domain.FinanceStats{UserID: 3})
+
+	_, ok = l.get(2)
+	assert.False(t, ok, "user 2 was read least recently")
+	_, ok = l.get(1)
+	assert.True(t, ok)
+	_, ok = l.get(3)
+	assert.True(t, ok)
+}
+
+func TestLRUBoundsBytes(t *testing.T) {
+	small := domain.FinanceStats{UserID: 1}
+	large := domain.FinanceStats{UserID: 2, ExpenseByCategory: map[string]float64{"food": 1, "rent": 2}}
+	l := newLRU(10, statsSize(small)+statsSize(large), time.Minute)
+
+	l.put(small)
+	l.put(large)
+	assert.Equal(t, statsSize(small)+statsSize(large), l.bytes)
+
+	// Replacing an entry frees its old size first.
+	l.put(domain.FinanceStats{UserID: 1})
+	assert.Equal(t, 2, l.order.Len())
+
+	l.put(domain.FinanceStats{UserID: 3})
+	_, ok := l.get(2)
+	assert.False(t, ok, "user 2 was written least recently")
+	assert.LessOrEqual(t, l.bytes, l.maxBytes)
+
+	// An entry larger than the whole tier is not kept.
+	huge := domain.FinanceStats{UserID: 4, IncomeByCategory: map[string]float64{}}
+	for i := range 100 {
+		huge.IncomeByCategory[string(rune('a'+i))] = 1
+	}
+	l.put(huge)
+	_, ok = l.get(4)
+	assert.False(t, ok)
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	l := newLRU(10, 1<<20, time.Minute)
+	l.now = func() time.Time { return now }
+	l.put(domain.FinanceStats{UserID: 1})
+
+	now = now.Add(59 * time.Second)
+	_, ok := l.get(1)
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = l.get(1)
+	assert.False(t, ok)
+	assert.Zero(t, l.bytes)
+}
+
+func TestLRUDropsFillsOverlappingInvalidation(t *testing.T) {
+	l := newLRU(10, 1<<20, time.Minute)
+	read := &domain.FinanceStats{UserID: 1, TotalIncome: 10}
+
+	generation := l.startFill(1)
+	l.endFill(1, generation, read)
+	_, ok := l.get(1)
+	assert.True(t, ok, "a read without invalidation is kept")
+
+	for name, invalidate := range map[string]func(){
+		"delete": func() { l.delete(1) },
+		"clear":  l.clear,
+		"put":    func() { l.put(domain.FinanceStats{UserID: 1, TotalIncome: 20}) },
+	} {
+		l.clear()
+		generation := l.startFill(1)
+		invalidate()
+		l.endFill(1, generation, read)
+		stats, ok := l.get(1)
+		assert.False(t, ok && stats.TotalIncome == 10, "the read from before the %s is dropped", name)
+	}
+
+	// A read that starts after the invalidation is kept even while an
+	// older one is still in flight.
+	l.clear()
+	older := l.startFill(1)
+	l.delete(1)
+	newer := l.startFill(1)
+	l.endFill(1, newer, &domain.FinanceStats{UserID: 1, TotalIncome: 20})
+	l.endFill(1, older, read)
+	stats, ok := l.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 20.0, stats.TotalIncome)
+	assert.Empty(t, l.fills)
+}
@@ -106,6 +106,74 @@ func (_c *StatsCache_Get_Call) RunAndReturn(run func(ctx context.Context, userID
 	return _c
 }
 
+// GetStale provides a mock function for the type StatsCache
+func (_mock *StatsCache) GetStale(ctx context.Context, userID int) (*domain.FinanceStats, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStale")
+	}
+
+	var r0 *domain.FinanceStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.FinanceStats, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.FinanceStats); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.FinanceStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// StatsCache_GetStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStale'
+type StatsCache_GetStale_Call struct {
+	*mock.Call
+}
+
+// GetStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *StatsCache_Expecter) GetStale(ctx interface{}, userID interface{}) *StatsCache_GetStale_Call {
+	return &StatsCache_GetStale_Call{Call: _e.mock.On("GetStale", ctx, userID)}
+}
+
+func (_c *StatsCache_GetStale_Call) Run(run func(ctx context.Context, userID int)) *StatsCache_GetStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *StatsCache_GetStale_Call) Return(financeStats *domain.FinanceStats, err error) *StatsCache_GetStale_Call {
+	_c.Call.Return(financeStats, err)
+	return _c
+}
+
+func (_c *StatsCache_GetStale_Call) RunAndReturn(run func(ctx context.Context, userID int) (*domain.FinanceStats, error)) *StatsCache_GetStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function for the type StatsCache
 func (_mock *StatsCache) Set(ctx context.Context, stats domain.FinanceStats) error {
 	ret := _mock.Called(ctx, stats)
@@ -162,3 +230,87 @@ func (_c *StatsCache_Set_Call) RunAndReturn(run func(ctx context.Context, stats
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewUpdatePublisher creates a new instance of UpdatePublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUpdatePublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UpdatePublisher {
+	mock := &UpdatePublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// UpdatePublisher is an autogenerated mock type for the UpdatePublisher type
+type UpdatePublisher struct {
+	mock.Mock
+}
+
+type UpdatePublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UpdatePublisher) EXPECT() *UpdatePublisher_Expecter {
+	return &UpdatePublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type UpdatePublisher
+func (_mock *UpdatePublisher) Publish(ctx context.Context, stats domain.FinanceStats) error {
+	ret := _mock.Called(ctx, stats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.FinanceStats) error); ok {
+		r0 = returnFunc(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UpdatePublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type UpdatePublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stats domain.FinanceStats
+func (_e *UpdatePublisher_Expecter) Publish(ctx interface{}, stats interface{}) *UpdatePublisher_Publish_Call {
+	return &UpdatePublisher_Publish_Call{Call: _e.mock.On("Publish", ctx, stats)}
+}
+
+func (_c *UpdatePublisher_Publish_Call) Run(run func(ctx context.Context, stats domain.FinanceStats)) *UpdatePublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.FinanceStats
+		if args[1] != nil {
+			arg1 = args[1].(domain.FinanceStats)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UpdatePublisher_Publish_Call) Return(err error) *UpdatePublisher_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UpdatePublisher_Publish_Call) RunAndReturn(run func(ctx context.Context, stats domain.FinanceStats) error) *UpdatePublisher_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
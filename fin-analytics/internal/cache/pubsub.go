@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// resubscribeDelay paces retries while Redis is unreachable.
+const resubscribeDelay = time.Second
+
+// subscribe passes the payload of every message on channel to onMessage
+// until stop is closed, resubscribing after failures. onLost, if set, is
+// called when the subscription fails and again once it is re-established:
+// messages published in between are lost.
+func subscribe(client *redis.Client, channel string, stop <-chan struct{}, onMessage func(string), onLost func()) {
+	if onLost == nil {
+		onLost = func() {}
+	}
+
+	ctx := context.Background()
+	pubsub := client.Subscribe(ctx, channel)
+	go func() {
+		<-stop
+		_ = pubsub.Close()
+	}()
+
+	subscribed := false
+	for {
+		msg, err := pubsub.Receive(ctx)
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if err != nil {
+			slog.Warn("Subscription failed, retrying", "channel", channel, "error", err)
+			onLost()
+			select {
+			case <-stop:
+				return
+			case <-time.After(resubscribeDelay):
+			}
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if subscribed {
+				onLost()
+			}
+			subscribed = true
+		case *redis.Message:
+			onMessage(msg.Payload)
+		}
+	}
+}
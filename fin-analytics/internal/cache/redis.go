@@ -4,28 +4,73 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
 )
 
+// ttlJitter spreads expiries by up to ±10% so that keys written together,
+// such as after a restart, don't all expire in the same second.
+const ttlJitter = 0.1
+
 type Cache struct {
-	client *redis.Client
-	ttl    time.Duration
+	client   *redis.Client
+	ttl      atomic.Int64
+	staleTTL time.Duration
+	metrics  *metrics.Metrics
+}
+
+func New(client *redis.Client, ttl, staleTTL time.Duration, m *metrics.Metrics) *Cache {
+	c := &Cache{client: client, staleTTL: staleTTL, metrics: m}
+	c.SetTTL(ttl)
+	return c
 }
 
-func New(client *redis.Client, ttl time.Duration) *Cache {
-	return &Cache{client: client, ttl: ttl}
+// SetTTL changes the expiry of entries written from now on; existing
+// entries keep theirs.
+func (c *Cache) SetTTL(ttl time.Duration) {
+	c.ttl.Store(int64(ttl))
 }
 
 func (c *Cache) key(userID int) string {
 	return fmt.Sprintf("fintrack:stats:%d", userID)
 }
 
+func (c *Cache) staleKey(userID int) string {
+	return fmt.Sprintf("fintrack:stats:stale:%d", userID)
+}
+
 func (c *Cache) Get(ctx context.Context, userID int) (*domain.FinanceStats, error) {
-	data, err := c.client.Get(ctx, c.key(userID)).Bytes()
+	stats, err := c.get(ctx, c.key(userID))
+	switch {
+	case err != nil:
+		c.metrics.CacheError()
+	case stats == nil:
+		c.metrics.CacheMiss()
+	default:
+		c.metrics.CacheHit()
+	}
+	return stats, err
+}
+
+func (c *Cache) GetStale(ctx context.Context, userID int) (*domain.FinanceStats, error) {
+	stats, err := c.get(ctx, c.staleKey(userID))
+	switch {
+	case err != nil:
+		c.metrics.CacheError()
+	case stats != nil:
+		c.metrics.CacheStale()
+	}
+	return stats, err
+}
+
+func (c *Cache) get(ctx context.Context, key string) (*domain.FinanceStats, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil
 	}
@@ -45,8 +90,17 @@ func (c *Cache) Set(ctx context.Context, stats domain.FinanceStats) error {
 	if err != nil {
 		return fmt.Errorf("marshal stats: %w", err)
 	}
-	if err := c.client.Set(ctx, c.key(stats.UserID), payload, c.ttl).Err(); err != nil {
+	_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.key(stats.UserID), payload, jitter(time.Duration(c.ttl.Load())))
+		pipe.Set(ctx, c.staleKey(stats.UserID), payload, jitter(c.staleTTL))
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("redis set: %w", err)
 	}
 	return nil
 }
+
+func jitter(ttl time.Duration) time.Duration {
+	return ttl + time.Duration((rand.Float64()*2-1)*ttlJitter*float64(ttl))
+}
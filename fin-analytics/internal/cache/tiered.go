@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	"fin-shared/config"
+	"fin-shared/logging"
+)
+
+// invalidationChannel carries "<replica id>:<user id>" for every Set, so
+// that the other replicas drop their local copy of the user's stats.
+const invalidationChannel = "fintrack:stats:invalidate"
+
+// Tiered keeps recently read stats in memory in front of the Redis Cache,
+// saving a round-trip and an unmarshal on every hit. A Kafka update is
+// processed by one replica only; its Set publishes on
+// invalidationChannel and Run on every other replica evicts the user.
+//
+// Stats loaded from fin-api are published too: they are rare next to
+// reads, and the replicas don't need to know where a Set came from.
+type Tiered struct {
+	redis   *Cache
+	local   *lru
+	id      string
+	metrics *metrics.Metrics
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewTiered(redis *Cache, cfg config.LocalCacheConfig, m *metrics.Metrics) *Tiered {
+	return &Tiered{
+		redis:   redis,
+		local:   newLRU(cfg.MaxEntries, cfg.MaxBytes, cfg.TTL),
+		id:      replicaID(),
+		metrics: m,
+		stop:    make(chan struct{}),
+	}
+}
+
+func replicaID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (t *Tiered) Get(ctx context.Context, userID int) (*domain.FinanceStats, error) {
+	if stats, ok := t.local.get(userID); ok {
+		t.metrics.CacheLocalHit()
+		return &stats, nil
+	}
+	// An invalidation that arrives during the read may be for a newer
+	// value than the one read, which then isn't kept in memory.
+	generation := t.local.startFill(userID)
+	stats, err := t.redis.Get(ctx, userID)
+	t.local.endFill(userID, generation, stats)
+	return stats, err
+}
+
+// GetStale is only read while fin-api is down and is not kept in memory.
+func (t *Tiered) GetStale(ctx context.Context, userID int) (*domain.FinanceStats, error) {
+	return t.redis.GetStale(ctx, userID)
+}
+
+func (t *Tiered) Set(ctx context.Context, stats domain.FinanceStats) error {
+	if err := t.redis.Set(ctx, stats); err != nil {
+		t.local.delete(stats.UserID)
+		return err
+	}
+	t.local.put(stats)
+
+	// Without the message other replicas serve their copy until
+	// cache.local.ttl, so a failure is not worth failing the update for.
+	message := t.id + ":" + strconv.Itoa(stats.UserID)
+	if err := t.redis.client.Publish(ctx, invalidationChannel, message).Err(); err != nil {
+		logging.FromContext(ctx).Warn("Failed to publish stats invalidation", "error", err)
+	}
+	return nil
+}
+
+// Run evicts the users other replicas publish until Stop is called. The
+// local tier is cleared when the subscription is lost and again once it is
+// re-established, since invalidations sent in between are lost.
+func (t *Tiered) Run() error {
+	subscribe(t.redis.client, invalidationChannel, t.stop, t.invalidate, t.local.clear)
+	return nil
+}
+
+func (t *Tiered) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+func (t *Tiered) invalidate(message string) {
+	origin, rawUserID, ok := strings.Cut(message, ":")
+	if !ok || origin == t.id {
+		return
+	}
+	userID, err := strconv.Atoi(rawUserID)
+	if err != nil {
+		slog.Warn("Invalid stats invalidation", "message", message)
+		return
+	}
+	t.local.delete(userID)
+}
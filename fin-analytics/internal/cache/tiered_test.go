@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-analytics/internal/domain"
+	"fin-shared/config"
+)
+
+func newReplica(t *testing.T, addr string) *Tiered {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	tiered := NewTiered(New(client, time.Hour, time.Hour, nil), config.LocalCacheConfig{MaxEntries: 10, MaxBytes: 1 << 20, TTL: time.Hour}, nil)
+	go func() { _ = tiered.Run() }()
+	t.Cleanup(tiered.Stop)
+	return tiered
+}
+
+func TestTieredServesFromMemory(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+	replica := newReplica(t, server.Addr())
+
+	require.NoError(t, replica.Set(ctx, domain.FinanceStats{UserID: 1, TotalIncome: 10}))
+	server.FlushAll()
+
+	stats, err := replica.Get(ctx, 1)
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, 10.0, stats.TotalIncome)
+}
+
+func TestTieredInvalidatesOtherReplicas(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+	writer, reader := newReplica(t, server.Addr()), newReplica(t, server.Addr())
+	require.Eventually(t, func() bool { return server.PubSubNumSub(invalidationChannel)[invalidationChannel] == 2 },
+		time.Second, 10*time.Millisecond)
+
+	require.NoError(t, writer.Set(ctx, domain.FinanceStats{UserID: 1, TotalIncome: 10}))
+	stats, err := reader.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 10.0, stats.TotalIncome)
+
+	// A Kafka update processed by the writer reaches the reader's memory.
+	require.NoError(t, writer.Set(ctx, domain.FinanceStats{UserID: 1, TotalIncome: 20}))
+	assert.Eventually(t, func() bool {
+		stats, err := reader.Get(ctx, 1)
+		return err == nil && stats.TotalIncome == 20
+	}, time.Second, 10*time.Millisecond)
+
+	// The writer keeps its own fresh copy.
+	server.FlushAll()
+	stats, err = writer.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, stats.TotalIncome)
+}
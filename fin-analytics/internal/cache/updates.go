@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"fin-analytics/internal/domain"
+)
+
+// updatesChannel carries the stats recalculated from every Kafka message,
+// so that WatchStats streams on any replica see them.
+const updatesChannel = "fintrack:stats:updates"
+
+// UpdatePublisher shares stats recalculated from Kafka with the other
+// replicas.
+type UpdatePublisher interface {
+	Publish(ctx context.Context, stats domain.FinanceStats) error
+}
+
+// Updates fans stats updates out across replicas. Only the replica that
+// consumes the user's partition recalculates the stats; it publishes them
+// and Run on every other replica hands them to the local watchers.
+type Updates struct {
+	client *redis.Client
+	id     string
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type update struct {
+	Origin string              `json:"origin"`
+	Stats  domain.FinanceStats `json:"stats"`
+}
+
+func NewUpdates(client *redis.Client) *Updates {
+	return &Updates{
+		client: client,
+		id:     replicaID(),
+		stop:   make(chan struct{}),
+	}
+}
+
+func (u *Updates) Publish(ctx context.Context, stats domain.FinanceStats) error {
+	data, err := json.Marshal(update{Origin: u.id, Stats: stats})
+	if err != nil {
+		return fmt.Errorf("marshal update: %w", err)
+	}
+	if err := u.client.Publish(ctx, updatesChannel, data).Err(); err != nil {
+		return fmt.Errorf("publish update: %w", err)
+	}
+	return nil
+}
+
+// Run passes the stats other replicas publish to deliver until Stop is
+// called. Updates published while the subscription is down are lost: a
+// watcher misses them and sees the next one.
+func (u *Updates) Run(deliver func(domain.FinanceStats)) error {
+	subscribe(u.client, updatesChannel, u.stop, func(payload string) {
+		var msg update
+		if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+			slog.Warn("Invalid stats update", "error", err)
+			return
+		}
+		if msg.Origin != u.id {
+			deliver(msg.Stats)
+		}
+	}, nil)
+	return nil
+}
+
+func (u *Updates) Stop() {
+	u.stopOnce.Do(func() { close(u.stop) })
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-analytics/internal/domain"
+)
+
+func newUpdates(t *testing.T, addr string) (*Updates, <-chan domain.FinanceStats) {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	updates := NewUpdates(client)
+	delivered := make(chan domain.FinanceStats, 10)
+	go func() { _ = updates.Run(func(stats domain.FinanceStats) { delivered <- stats }) }()
+	t.Cleanup(updates.Stop)
+	return updates, delivered
+}
+
+func TestUpdatesReachOtherReplicas(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+	consumer, consumerDelivered := newUpdates(t, server.Addr())
+	_, otherDelivered := newUpdates(t, server.Addr())
+	require.Eventually(t, func() bool { return server.PubSubNumSub(updatesChannel)[updatesChannel] == 2 },
+		time.Second, 10*time.Millisecond)
+
+	require.NoError(t, consumer.Publish(ctx, domain.FinanceStats{UserID: 1, TotalIncome: 10}))
+
+	select {
+	case stats := <-otherDelivered:
+		assert.Equal(t, 1, stats.UserID)
+		assert.Equal(t, 10.0, stats.TotalIncome)
+	case <-time.After(time.Second):
+		t.Fatal("update was not delivered to the other replica")
+	}
+	// The consumer already notified its own watchers.
+	assert.Never(t, func() bool { return len(consumerDelivered) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -2,16 +2,18 @@ package database
 
 import (
 	"context"
-	"fin-analytics/config"
+	"fin-shared/config"
 	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:         cfg.Addr(),
+		Password:     cfg.Password,
 		DB:           cfg.DB,
 		DialTimeout:  5 * time.Second,
 		ReadTimeout:  5 * time.Second,
@@ -19,6 +21,10 @@ func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*redis.Client,
 		PoolSize:     10,
 	})
 
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("instrument redis: %w", err)
+	}
+
 	if err := client.Ping(ctx).Err(); err != nil {
 		return nil, fmt.Errorf("ping redis: %w", err)
 	}
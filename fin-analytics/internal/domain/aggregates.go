@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Aggregates are the precomputed stats of a user kept in the analytics
+// store. FinanceStats and monthly time series are derived from them
+// without the user's transactions.
+type Aggregates struct {
+	UserID  int
+	Income  Total
+	Expense Total
+	// Categories holds one total per type and category over all months.
+	Categories []CategoryTotal
+	// Months holds one rollup per month, type and category.
+	Months []CategoryMonth
+	// Version is the user's version in fin-api the aggregates reflect:
+	// the number of writes to the user's transactions.
+	Version int64
+}
+
+// Total is the sum, the number and the smallest and largest amount of a
+// set of transactions. Min and Max are zero when Count is.
+type Total struct {
+	Sum   float64
+	Count int
+	Min   float64
+	Max   float64
+}
+
+// CategoryMonth totals the transactions of one type and category created
+// in the calendar month starting at Month, in UTC.
+type CategoryMonth struct {
+	Month    time.Time
+	Type     TransactionType
+	Category string
+	Total
+}
+
+// MonthKey identifies the CategoryMonth of a transaction.
+type MonthKey struct {
+	Month    time.Time
+	Type     TransactionType
+	Category string
+}
+
+// CategoryTotal sums the transactions of one type and category.
+type CategoryTotal struct {
+	Type     TransactionType
+	Category string
+	Sum      float64
+	Count    int
+}
+
+// AggregatesDelta is what one change did to a user's aggregates: the
+// totals after it and the category totals and rollups it touched, at most
+// two of each. Those the change emptied have a zero Count.
+type AggregatesDelta struct {
+	UserID     int
+	Income     Total
+	Expense    Total
+	Categories []CategoryTotal
+	Months     []CategoryMonth
+	Version    int64
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+// ErrAggregatesNotFound is returned by the analytics store for users it
+// has no aggregates for yet.
+var ErrAggregatesNotFound = errors.New("aggregates not found")
@@ -20,9 +20,60 @@ type Transaction struct {
 	CreatedAt time.Time       `json:"created_at"`
 }
 
+// TransactionMessage is the event fin-api publishes for every write to a
+// user's transactions. It carries the write only; the user's transactions
+// are streamed from fin-api when the aggregates can't follow the events.
 type TransactionMessage struct {
-	UserID       int           `json:"user_id"`
-	Transactions []Transaction `json:"transactions"`
+	UserID int `json:"user_id"`
+	// Version is the user's version in fin-api after Change. It counts the
+	// writes to the user's transactions, so an event for a user follows
+	// the one before it by exactly one. It is zero in messages from
+	// fin-api versions that predate it.
+	Version int64 `json:"version"`
+	// Change is nil in messages from fin-api versions that predate it.
+	Change *TransactionChange `json:"change,omitempty"`
+}
+
+// TransactionBatch is part of a user's transactions streamed from fin-api
+// as of Version.
+type TransactionBatch struct {
+	Version      int64
+	Transactions []Transaction
+}
+
+type ChangeOp string
+
+const (
+	ChangeCreate ChangeOp = "create"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// TransactionChange is the single write behind a TransactionMessage.
+// Transaction is the created, updated or deleted transaction; Previous is
+// set on updates only.
+type TransactionChange struct {
+	Op          ChangeOp     `json:"op"`
+	Transaction Transaction  `json:"transaction"`
+	Previous    *Transaction `json:"previous,omitempty"`
+}
+
+// Interval is the period a time series is bucketed by.
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// TimeSeriesPoint aggregates the transactions of one period.
+type TimeSeriesPoint struct {
+	PeriodStart       time.Time `json:"period_start"`
+	Income            float64   `json:"income"`
+	Expense           float64   `json:"expense"`
+	Balance           float64   `json:"balance"`
+	TransactionsCount int       `json:"transactions_count"`
 }
 
 type FinanceStats struct {
@@ -36,4 +87,7 @@ type FinanceStats struct {
 	IncomeByCategory  map[string]float64 `json:"income_by_category"`
 	TransactionsCount int                `json:"transactions_count"`
 	GeneratedAt       time.Time          `json:"generated_at"`
+	// Stale is set on stats served from the stale copy while fin-api is
+	// unavailable; it is never cached.
+	Stale bool `json:"stale,omitempty"`
 }
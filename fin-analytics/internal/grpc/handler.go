@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fin-analytics/internal/domain"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+)
+
+// unbounded stands in for an empty TimeSeriesRequest.to.
+var unbounded = time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (s *Server) GetStats(ctx context.Context, req *fintrackv1.StatsRequest) (*fintrackv1.FinanceStats, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.service.GetStats(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertStats(stats), nil
+}
+
+func (s *Server) GetTimeSeries(ctx context.Context, req *fintrackv1.TimeSeriesRequest) (*fintrackv1.TimeSeries, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	interval, err := intervalFromProto(req.GetInterval())
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseBound("from", req.GetFrom(), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseBound("to", req.GetTo(), unbounded)
+	if err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	points, err := s.service.GetTimeSeries(ctx, userID, interval, from, to)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	series := &fintrackv1.TimeSeries{
+		UserId:   req.GetUserId(),
+		Interval: req.GetInterval(),
+		Points:   make([]*fintrackv1.TimeSeriesPoint, 0, len(points)),
+	}
+	for _, point := range points {
+		series.Points = append(series.Points, &fintrackv1.TimeSeriesPoint{
+			PeriodStart:       point.PeriodStart.Format(time.RFC3339),
+			Income:            point.Income,
+			Expense:           point.Expense,
+			Balance:           point.Balance,
+			TransactionsCount: int64(point.TransactionsCount),
+		})
+	}
+	return series, nil
+}
+
+// WatchStats sends the current stats, then every recalculation until the
+// client cancels or the server stops. Subscribing before reading the current stats means an
+// update that lands in between is not lost.
+func (s *Server) WatchStats(req *fintrackv1.StatsRequest, stream fintrackv1.AnalyticsService_WatchStatsServer) error {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+
+	updates, cancel := s.service.Watch(userID)
+	defer cancel()
+
+	stats, err := s.service.GetStats(ctx, userID)
+	if err != nil {
+		return statusError(err)
+	}
+	if err := stream.Send(convertStats(stats)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server shutting down")
+		case stats := <-updates:
+			if err := stream.Send(convertStats(stats)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func userIDFromRequest(id int64) (int, error) {
+	if id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "user_id must be positive")
+	}
+	return int(id), nil
+}
+
+func intervalFromProto(interval fintrackv1.Interval) (domain.Interval, error) {
+	switch interval {
+	case fintrackv1.Interval_INTERVAL_DAY:
+		return domain.IntervalDay, nil
+	case fintrackv1.Interval_INTERVAL_WEEK:
+		return domain.IntervalWeek, nil
+	case fintrackv1.Interval_INTERVAL_MONTH:
+		return domain.IntervalMonth, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unsupported interval %s", interval)
+	}
+}
+
+func parseBound(name, value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, fmt.Sprintf("%s: %v", name, err))
+	}
+	return t, nil
+}
+
+// statusError keeps the code of errors that already carry one, such as a
+// fin-api call that failed with Unavailable.
+func statusError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func convertStats(stats domain.FinanceStats) *fintrackv1.FinanceStats {
+	return &fintrackv1.FinanceStats{
+		UserId:            int64(stats.UserID),
+		TotalIncome:       stats.TotalIncome,
+		TotalExpense:      stats.TotalExpense,
+		Balance:           stats.Balance,
+		AverageIncome:     stats.AverageIncome,
+		AverageExpense:    stats.AverageExpense,
+		ExpenseByCategory: stats.ExpenseByCategory,
+		IncomeByCategory:  stats.IncomeByCategory,
+		TransactionsCount: int64(stats.TransactionsCount),
+		GeneratedAt:       stats.GeneratedAt.Format(time.RFC3339),
+		Stale:             stats.Stale,
+	}
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	stdgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	"fin-shared/auth"
+	"fin-shared/logging"
+)
+
+type AnalyticsService interface {
+	GetStats(ctx context.Context, userID int) (domain.FinanceStats, error)
+	GetTimeSeries(ctx context.Context, userID int, interval domain.Interval, from, to time.Time) ([]domain.TimeSeriesPoint, error)
+	Watch(userID int) (<-chan domain.FinanceStats, func())
+}
+
+type Server struct {
+	fintrackv1.UnimplementedAnalyticsServiceServer
+	service AnalyticsService
+	server  *stdgrpc.Server
+	// done is closed by Stop so that WatchStats streams end and don't
+	// hold GracefulStop until ctx expires.
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewServer builds the AnalyticsService server. Every call needs an API
+// key with the stats scope. A nil tlsConfig serves plaintext.
+func NewServer(service AnalyticsService, authenticator *auth.Authenticator, tlsConfig *tls.Config, m *metrics.Metrics) *Server {
+	s := &Server{
+		service: service,
+		done:    make(chan struct{}),
+		server: stdgrpc.NewServer(
+			serverCreds(tlsConfig),
+			stdgrpc.StatsHandler(otelgrpc.NewServerHandler()),
+			stdgrpc.ChainUnaryInterceptor(
+				m.UnaryServerInterceptor(),
+				logging.UnaryServerInterceptor(),
+				authenticator.UnaryServerInterceptor(auth.ScopeStats),
+			),
+			stdgrpc.ChainStreamInterceptor(
+				m.StreamServerInterceptor(),
+				logging.StreamServerInterceptor(),
+				authenticator.StreamServerInterceptor(auth.ScopeStats),
+			),
+		),
+	}
+	fintrackv1.RegisterAnalyticsServiceServer(s.server, s)
+	return s
+}
+
+func serverCreds(tlsConfig *tls.Config) stdgrpc.ServerOption {
+	if tlsConfig == nil {
+		return stdgrpc.Creds(insecure.NewCredentials())
+	}
+	return stdgrpc.Creds(credentials.NewTLS(tlsConfig))
+}
+
+// Start serves on addr until Stop is called.
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen grpc: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on listener until Stop is called.
+func (s *Server) Serve(listener net.Listener) error {
+	if err := s.server.Serve(listener); !errors.Is(err, stdgrpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Stop ends the WatchStats streams with Unavailable, so clients reconnect
+// to another replica, and waits for the other in-flight RPCs to finish.
+// Once ctx expires the remaining RPCs are cancelled.
+func (s *Server) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.done) })
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,167 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stdgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	"fin-shared/auth"
+	"fin-shared/config"
+)
+
+type fakeService struct {
+	stats    domain.FinanceStats
+	points   []domain.TimeSeriesPoint
+	interval domain.Interval
+	updates  chan domain.FinanceStats
+}
+
+func (f *fakeService) GetStats(ctx context.Context, userID int) (domain.FinanceStats, error) {
+	if userID == 404 {
+		return domain.FinanceStats{}, status.Error(codes.Unavailable, "fin-api down")
+	}
+	return f.stats, nil
+}
+
+func (f *fakeService) GetTimeSeries(ctx context.Context, userID int, interval domain.Interval, from, to time.Time) ([]domain.TimeSeriesPoint, error) {
+	f.interval = interval
+	return f.points, nil
+}
+
+func (f *fakeService) Watch(userID int) (<-chan domain.FinanceStats, func()) {
+	return f.updates, func() {}
+}
+
+func startServer(t *testing.T, svc AnalyticsService) fintrackv1.AnalyticsServiceClient {
+	t.Helper()
+	_, client := serve(t, svc)
+	return client
+}
+
+func serve(t *testing.T, svc AnalyticsService) (*Server, fintrackv1.AnalyticsServiceClient) {
+	t.Helper()
+	authenticator := auth.New([]config.APIKeyConfig{
+		{Name: "stats", Key: "stats-key", Scopes: []string{auth.ScopeStats}},
+		{Name: "admin", Key: "admin-key", Scopes: []string{auth.ScopeAdmin}},
+	})
+	server := NewServer(svc, authenticator, nil, metrics.New())
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Stop(context.Background()) })
+
+	conn, err := stdgrpc.NewClient("passthrough:///bufnet",
+		stdgrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		stdgrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return server, fintrackv1.NewAnalyticsServiceClient(conn)
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+}
+
+func TestGetStatsRequiresStatsScope(t *testing.T) {
+	client := startServer(t, &fakeService{stats: domain.FinanceStats{UserID: 1, TotalIncome: 10}})
+
+	_, err := client.GetStats(context.Background(), &fintrackv1.StatsRequest{UserId: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetStats(withKey("admin-key"), &fintrackv1.StatsRequest{UserId: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stats, err := client.GetStats(withKey("stats-key"), &fintrackv1.StatsRequest{UserId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, stats.GetTotalIncome())
+
+	_, err = client.GetStats(withKey("stats-key"), &fintrackv1.StatsRequest{UserId: 404})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestGetTimeSeries(t *testing.T) {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	svc := &fakeService{points: []domain.TimeSeriesPoint{{PeriodStart: start, Income: 5, Balance: 5, TransactionsCount: 1}}}
+	client := startServer(t, svc)
+	ctx := withKey("stats-key")
+
+	series, err := client.GetTimeSeries(ctx, &fintrackv1.TimeSeriesRequest{UserId: 1, Interval: fintrackv1.Interval_INTERVAL_WEEK})
+	require.NoError(t, err)
+	assert.Equal(t, domain.IntervalWeek, svc.interval)
+	require.Len(t, series.GetPoints(), 1)
+	assert.Equal(t, "2024-03-04T00:00:00Z", series.GetPoints()[0].GetPeriodStart())
+
+	_, err = client.GetTimeSeries(ctx, &fintrackv1.TimeSeriesRequest{UserId: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetTimeSeries(ctx, &fintrackv1.TimeSeriesRequest{UserId: 1, Interval: fintrackv1.Interval_INTERVAL_DAY, From: "yesterday"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetTimeSeries(ctx, &fintrackv1.TimeSeriesRequest{
+		UserId:   1,
+		Interval: fintrackv1.Interval_INTERVAL_DAY,
+		From:     "2024-03-02T00:00:00Z",
+		To:       "2024-03-01T00:00:00Z",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchStatsSendsCurrentThenUpdates(t *testing.T) {
+	svc := &fakeService{
+		stats:   domain.FinanceStats{UserID: 1, TotalIncome: 10},
+		updates: make(chan domain.FinanceStats, 1),
+	}
+	client := startServer(t, svc)
+
+	ctx, cancel := context.WithCancel(withKey("stats-key"))
+	defer cancel()
+	stream, err := client.WatchStats(ctx, &fintrackv1.StatsRequest{UserId: 1})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, first.GetTotalIncome())
+
+	svc.updates <- domain.FinanceStats{UserID: 1, TotalIncome: 25}
+	next, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, next.GetTotalIncome())
+
+	cancel()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestStopEndsWatchStreams(t *testing.T) {
+	svc := &fakeService{stats: domain.FinanceStats{UserID: 1}, updates: make(chan domain.FinanceStats)}
+	server, client := serve(t, svc)
+
+	stream, err := client.WatchStats(withKey("stats-key"), &fintrackv1.StatsRequest{UserId: 1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	require.NoError(t, server.Stop(ctx), "an idle watcher held the shutdown")
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,81 @@
+package grpcclient
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned without calling fin-api while the breaker is
+// open. It carries codes.Unavailable so that it is handled like fin-api
+// being down.
+var ErrCircuitOpen = status.Error(codes.Unavailable, "fin-api circuit breaker is open")
+
+// IsUnavailable reports whether err means fin-api could not answer, as
+// opposed to fin-api rejecting the request.
+func IsUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// breaker opens after threshold consecutive calls that failed with
+// IsUnavailable. Once openTimeout has passed it lets a single trial call
+// through: success closes it, failure opens it again.
+type breaker struct {
+	mu          sync.Mutex
+	threshold   int
+	openTimeout time.Duration
+	failures    int
+	openedAt    time.Time
+	trial       bool
+	onChange    func(open bool)
+	now         func() time.Time
+}
+
+func newBreaker(threshold int, openTimeout time.Duration, onChange func(open bool)) *breaker {
+	return &breaker{threshold: threshold, openTimeout: openTimeout, onChange: onChange, now: time.Now}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || b.now().Sub(b.openedAt) < b.openTimeout {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasOpen := !b.openedAt.IsZero()
+	b.trial = false
+	if !IsUnavailable(err) {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		if wasOpen {
+			b.onChange(false)
+		}
+		return
+	}
+
+	b.failures++
+	if wasOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		if !wasOpen {
+			b.onChange(true)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package grpcclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var changes []bool
+	b := newBreaker(2, 30*time.Second, func(open bool) { changes = append(changes, open) })
+	b.now = func() time.Time { return now }
+	down := status.Error(codes.Unavailable, "connection refused")
+
+	assert.True(t, b.allow())
+	b.record(status.Error(codes.NotFound, "no such user"))
+	b.record(down)
+	assert.True(t, b.allow(), "one failure is below the threshold")
+	b.record(down)
+	assert.False(t, b.allow())
+
+	now = now.Add(31 * time.Second)
+	assert.True(t, b.allow(), "trial call after open_timeout")
+	assert.False(t, b.allow(), "only one trial at a time")
+	b.record(down)
+	assert.False(t, b.allow(), "a failed trial reopens the breaker")
+
+	now = now.Add(31 * time.Second)
+	assert.True(t, b.allow())
+	b.record(nil)
+	assert.True(t, b.allow())
+	assert.Equal(t, []bool{true, false}, changes)
+}
+
+func TestIsUnavailable(t *testing.T) {
+	assert.True(t, IsUnavailable(ErrCircuitOpen))
+	assert.True(t, IsUnavailable(status.Error(codes.DeadlineExceeded, "slow")))
+	assert.False(t, IsUnavailable(status.Error(codes.InvalidArgument, "bad user")))
+	assert.False(t, IsUnavailable(errors.New("transaction 1: created_at: invalid")))
+	assert.False(t, IsUnavailable(nil))
+}
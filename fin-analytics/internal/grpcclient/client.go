@@ -2,45 +2,212 @@ package grpcclient
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 
-	"fin-analytics/api/proto"
 	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	fintrackv2 "fin-shared/api/fintrack/v2"
+	"fin-shared/config"
 )
 
 type Client struct {
-	client proto.TransactionServiceClient
+	conn    *grpc.ClientConn
+	client  fintrackv2.TransactionServiceClient
+	health  healthpb.HealthClient
+	timeout time.Duration
+	breaker *breaker
 }
 
-func New(addr string) (*Client, error) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+// New connects to fin-api at target, or spreads calls over endpoints when
+// the list is not empty. A DNS target such as dns:///fin-api:9090 resolves
+// to every instance behind the name, and both are balanced with
+// cfg.LoadBalancing.
+//
+// A nil tlsConfig connects in plaintext. Otherwise fin-api's certificate
+// must be valid for the host in target, or for "fin-api" with endpoints.
+func New(target string, endpoints []string, cfg config.GRPCClientConfig, tlsConfig *tls.Config, m *metrics.Metrics) (*Client, error) {
+	sc, err := serviceConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("dial grpc server: %w", err)
+		return nil, err
 	}
-	return &Client{client: proto.NewTransactionServiceClient(conn)}, nil
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(sc),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.Keepalive.Time,
+			Timeout:             cfg.Keepalive.Timeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(m.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(m.StreamClientInterceptor()),
+	}
+	if len(endpoints) > 0 {
+		r := manual.NewBuilderWithScheme("fin-api")
+		addrs := make([]resolver.Address, len(endpoints))
+		for i, endpoint := range endpoints {
+			addrs[i] = resolver.Address{Addr: endpoint}
+		}
+		r.InitialState(resolver.State{Addresses: addrs})
+		opts = append(opts, grpc.WithResolvers(r))
+		target = r.Scheme() + ":///fin-api"
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client: %w", err)
+	}
+	return &Client{
+		conn:    conn,
+		client:  fintrackv2.NewTransactionServiceClient(conn),
+		health:  healthpb.NewHealthClient(conn),
+		timeout: cfg.Timeout,
+		breaker: newBreaker(cfg.Breaker.FailureThreshold, cfg.Breaker.OpenTimeout, m.SetBreakerOpen),
+	}, nil
+}
+
+// retryableMethods only lists reads: retrying them cannot apply a change
+// twice.
+var retryableMethods = []map[string]string{
+	{"service": fintrackv2.TransactionService_ServiceDesc.ServiceName, "method": "GetTransaction"},
+	{"service": fintrackv2.TransactionService_ServiceDesc.ServiceName, "method": "ListTransactions"},
+	{"service": fintrackv2.TransactionService_ServiceDesc.ServiceName, "method": "StreamUserTransactions"},
+	{"service": fintrackv1.TransactionService_ServiceDesc.ServiceName, "method": "GetUserTransactions"},
+	{"service": healthpb.Health_ServiceDesc.ServiceName, "method": "Check"},
+}
+
+func serviceConfig(cfg config.GRPCClientConfig) (string, error) {
+	sc := map[string]any{
+		"loadBalancingConfig": []map[string]any{{cfg.LoadBalancing: map[string]any{}}},
+	}
+	// gRPC rejects a retry policy with fewer than two attempts.
+	if cfg.Retry.MaxAttempts > 1 {
+		sc["methodConfig"] = []map[string]any{{
+			"name": retryableMethods,
+			"retryPolicy": map[string]any{
+				"maxAttempts":          cfg.Retry.MaxAttempts,
+				"initialBackoff":       durationJSON(cfg.Retry.InitialBackoff),
+				"maxBackoff":           durationJSON(cfg.Retry.MaxBackoff),
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}}
+	}
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("encode grpc service config: %w", err)
+	}
+	return string(data), nil
+}
+
+// durationJSON formats d the way the service config expects, e.g. "0.1s".
+func durationJSON(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
 }
 
-func (c *Client) FetchTransactions(ctx context.Context, userID int) ([]domain.Transaction, error) {
-	response, err := c.client.GetUserTransactions(ctx, &proto.UserRequest{UserId: int64(userID)})
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Ping asks fin-api's standard gRPC health service whether
+// TransactionService is serving.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{Service: fintrackv2.TransactionService_ServiceDesc.ServiceName})
+	if err != nil {
+		return fmt.Errorf("grpc health check: %w", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s is %s", fintrackv2.TransactionService_ServiceDesc.ServiceName, resp.GetStatus())
+	}
+	return nil
+}
+
+// StreamTransactions reads the user's history from StreamUserTransactions
+// and passes each batch to fn as it arrives, so neither side holds the
+// whole history. The whole stream must finish within the configured
+// timeout. While the breaker is open it fails with ErrCircuitOpen without
+// calling fin-api.
+func (c *Client) StreamTransactions(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error) error {
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+	err := c.streamTransactions(ctx, userID, minVersion, fn)
+	c.breaker.record(err)
+	return err
+}
+
+func (c *Client) streamTransactions(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	stream, err := c.client.StreamUserTransactions(ctx, &fintrackv2.UserRequest{UserId: int64(userID), MinVersion: minVersion})
 	if err != nil {
-		return nil, fmt.Errorf("grpc get transactions: %w", err)
+		return fmt.Errorf("grpc stream transactions: %w", err)
+	}
+
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("grpc stream transactions: %w", err)
+		}
+		transactions := make([]domain.Transaction, 0, len(batch.GetTransactions()))
+		for _, tx := range batch.GetTransactions() {
+			converted, err := convertTransaction(tx)
+			if err != nil {
+				return fmt.Errorf("transaction %d: %w", tx.GetId(), err)
+			}
+			transactions = append(transactions, converted)
+		}
+		if err := fn(domain.TransactionBatch{Version: batch.GetVersion(), Transactions: transactions}); err != nil {
+			return err
+		}
+	}
+}
+
+func convertTransaction(tx *fintrackv2.Transaction) (domain.Transaction, error) {
+	if err := tx.GetCreatedAt().CheckValid(); err != nil {
+		return domain.Transaction{}, fmt.Errorf("created_at: %w", err)
 	}
 
-	transactions := make([]domain.Transaction, 0, len(response.GetTransactions()))
-	for _, tx := range response.GetTransactions() {
-		parsed, _ := time.Parse(time.RFC3339, tx.GetCreatedAt())
-		transactions = append(transactions, domain.Transaction{
-			ID:        tx.GetId(),
-			UserID:    int(tx.GetUserId()),
-			Amount:    tx.GetAmount(),
-			Category:  tx.GetCategory(),
-			Type:      domain.TransactionType(tx.GetType()),
-			CreatedAt: parsed,
-		})
+	var txType domain.TransactionType
+	switch tx.GetType() {
+	case fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME:
+		txType = domain.TransactionTypeIncome
+	case fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE:
+		txType = domain.TransactionTypeExpense
+	default:
+		return domain.Transaction{}, fmt.Errorf("unsupported type %s", tx.GetType())
 	}
 
-	return transactions, nil
+	amount := tx.GetAmount()
+	return domain.Transaction{
+		ID:        tx.GetId(),
+		UserID:    int(tx.GetUserId()),
+		Amount:    float64(amount.GetUnits()) + float64(amount.GetNanos())/1e9,
+		Category:  tx.GetCategory(),
+		Type:      txType,
+		CreatedAt: tx.GetCreatedAt().AsTime(),
+	}, nil
 }
@@ -0,0 +1,199 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	fintrackv2 "fin-shared/api/fintrack/v2"
+	"fin-shared/config"
+)
+
+func TestPingUsesHealthService(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	healthServer := grpchealth.NewServer()
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := &Client{health: healthpb.NewHealthClient(conn)}
+	service := fintrackv2.TransactionService_ServiceDesc.ServiceName
+
+	healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	assert.NoError(t, client.Ping(context.Background()))
+
+	healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Error(t, client.Ping(context.Background()))
+}
+
+type streamingServer struct {
+	fintrackv2.UnimplementedTransactionServiceServer
+	batches [][]*fintrackv2.Transaction
+}
+
+func (s *streamingServer) StreamUserTransactions(req *fintrackv2.UserRequest, stream fintrackv2.TransactionService_StreamUserTransactionsServer) error {
+	for _, batch := range s.batches {
+		if err := stream.Send(&fintrackv2.TransactionBatch{Transactions: batch, Version: req.GetMinVersion()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamTransactionsPassesEveryBatch(t *testing.T) {
+	at := func(hour int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2026, time.March, 1, hour, 0, 0, 0, time.UTC))
+	}
+	income, expense := fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME, fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE
+	m := metrics.New()
+	client := newStreamingClient(t, m, [][]*fintrackv2.Transaction{
+		{
+			{Id: 3, UserId: 1, Amount: &fintrackv2.Money{Units: 30}, Type: income, CreatedAt: at(12)},
+			{Id: 2, UserId: 1, Amount: &fintrackv2.Money{Units: 20, Nanos: 500000000}, Type: expense, CreatedAt: at(11)},
+		},
+		{
+			{Id: 1, UserId: 1, Amount: &fintrackv2.Money{Units: 10}, Type: expense, CreatedAt: at(10)},
+		},
+	})
+
+	var batches []int
+	var txs []domain.Transaction
+	err := client.StreamTransactions(context.Background(), 1, 9, func(batch domain.TransactionBatch) error {
+		assert.Equal(t, int64(9), batch.Version, "the min version is sent")
+		batches = append(batches, len(batch.Transactions))
+		txs = append(txs, batch.Transactions...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, batches)
+	assert.Equal(t, []int64{3, 2, 1}, []int64{txs[0].ID, txs[1].ID, txs[2].ID})
+	assert.Equal(t, 20.5, txs[1].Amount)
+	assert.Equal(t, time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC), txs[2].CreatedAt)
+	assert.Equal(t, domain.TransactionTypeExpense, txs[2].Type)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Registry, "fin_analytics_grpc_client_handling_seconds"))
+}
+
+func TestStreamTransactionsStopsOnCallbackError(t *testing.T) {
+	tx := &fintrackv2.Transaction{Id: 1, UserId: 1, Amount: &fintrackv2.Money{Units: 1}, Type: fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME, CreatedAt: timestamppb.Now()}
+	client := newStreamingClient(t, nil, [][]*fintrackv2.Transaction{{tx}, {tx}})
+	saveErr := errors.New("store down")
+
+	var batches int
+	err := client.StreamTransactions(context.Background(), 1, 0, func(domain.TransactionBatch) error {
+		batches++
+		return saveErr
+	})
+	assert.ErrorIs(t, err, saveErr)
+	assert.Equal(t, 1, batches)
+}
+
+func TestStreamTransactionsRejectsMissingTimestamp(t *testing.T) {
+	client := newStreamingClient(t, nil, [][]*fintrackv2.Transaction{{
+		{Id: 7, UserId: 1, Amount: &fintrackv2.Money{Units: 1}, Type: fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME},
+	}})
+
+	err := client.StreamTransactions(context.Background(), 1, 0, ignoreBatch)
+	assert.ErrorContains(t, err, "transaction 7: created_at")
+}
+
+func ignoreBatch(domain.TransactionBatch) error { return nil }
+
+func newStreamingClient(t *testing.T, m *metrics.Metrics, batches [][]*fintrackv2.Transaction) *Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	fintrackv2.RegisterTransactionServiceServer(server, &streamingServer{batches: batches})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainStreamInterceptor(m.StreamClientInterceptor()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &Client{
+		client:  fintrackv2.NewTransactionServiceClient(conn),
+		timeout: time.Second,
+		breaker: newBreaker(5, time.Minute, func(bool) {}),
+	}
+}
+
+// flakyServer fails the first calls with Unavailable, as fin-api does
+// while an instance restarts.
+type flakyServer struct {
+	fintrackv2.UnimplementedTransactionServiceServer
+	failures atomic.Int32
+	calls    atomic.Int32
+}
+
+func (s *flakyServer) StreamUserTransactions(req *fintrackv2.UserRequest, stream fintrackv2.TransactionService_StreamUserTransactionsServer) error {
+	if s.calls.Add(1) <= s.failures.Load() {
+		return status.Error(codes.Unavailable, "restarting")
+	}
+	return stream.Send(&fintrackv2.TransactionBatch{})
+}
+
+func TestStreamTransactionsRetriesUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	flaky := &flakyServer{}
+	flaky.failures.Store(2)
+	server := grpc.NewServer()
+	fintrackv2.RegisterTransactionServiceServer(server, flaky)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	cfg := config.GRPCClientConfig{
+		Timeout:       5 * time.Second,
+		LoadBalancing: "round_robin",
+		Retry:         config.RetryConfig{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond},
+		Keepalive:     config.KeepaliveConfig{Time: 30 * time.Second, Timeout: 10 * time.Second},
+		Breaker:       config.BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute},
+	}
+	client, err := New("", []string{listener.Addr().String()}, cfg, nil, nil)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	err = client.StreamTransactions(context.Background(), 1, 0, ignoreBatch)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), flaky.calls.Load())
+
+	flaky.failures.Store(10)
+	err = client.StreamTransactions(context.Background(), 1, 0, ignoreBatch)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	err = client.StreamTransactions(context.Background(), 1, 0, ignoreBatch)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(6), flaky.calls.Load(), "the open breaker does not call fin-api")
+}
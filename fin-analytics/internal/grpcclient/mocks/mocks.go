@@ -38,47 +38,38 @@ func (_m *TransactionClient) EXPECT() *TransactionClient_Expecter {
 	return &TransactionClient_Expecter{mock: &_m.Mock}
 }
 
-// FetchTransactions provides a mock function for the type TransactionClient
-func (_mock *TransactionClient) FetchTransactions(ctx context.Context, userID int) ([]domain.Transaction, error) {
-	ret := _mock.Called(ctx, userID)
+// StreamTransactions provides a mock function for the type TransactionClient
+func (_mock *TransactionClient) StreamTransactions(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error) error {
+	ret := _mock.Called(ctx, userID, minVersion, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FetchTransactions")
+		panic("no return value specified for StreamTransactions")
 	}
 
-	var r0 []domain.Transaction
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]domain.Transaction, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []domain.Transaction); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Transaction)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64, func(domain.TransactionBatch) error) error); ok {
+		r0 = returnFunc(ctx, userID, minVersion, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// TransactionClient_FetchTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchTransactions'
-type TransactionClient_FetchTransactions_Call struct {
+// TransactionClient_StreamTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamTransactions'
+type TransactionClient_StreamTransactions_Call struct {
 	*mock.Call
 }
 
-// FetchTransactions is a helper method to define mock.On call
+// StreamTransactions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int
-func (_e *TransactionClient_Expecter) FetchTransactions(ctx interface{}, userID interface{}) *TransactionClient_FetchTransactions_Call {
-	return &TransactionClient_FetchTransactions_Call{Call: _e.mock.On("FetchTransactions", ctx, userID)}
+//   - minVersion int64
+//   - fn func(domain.TransactionBatch) error
+func (_e *TransactionClient_Expecter) StreamTransactions(ctx interface{}, userID interface{}, minVersion interface{}, fn interface{}) *TransactionClient_StreamTransactions_Call {
+	return &TransactionClient_StreamTransactions_Call{Call: _e.mock.On("StreamTransactions", ctx, userID, minVersion, fn)}
 }
 
-func (_c *TransactionClient_FetchTransactions_Call) Run(run func(ctx context.Context, userID int)) *TransactionClient_FetchTransactions_Call {
+func (_c *TransactionClient_StreamTransactions_Call) Run(run func(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error)) *TransactionClient_StreamTransactions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -88,20 +79,30 @@ func (_c *TransactionClient_FetchTransactions_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 func(domain.TransactionBatch) error
+		if args[3] != nil {
+			arg3 = args[3].(func(domain.TransactionBatch) error)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *TransactionClient_FetchTransactions_Call) Return(transactions []domain.Transaction, err error) *TransactionClient_FetchTransactions_Call {
-	_c.Call.Return(transactions, err)
+func (_c *TransactionClient_StreamTransactions_Call) Return(err error) *TransactionClient_StreamTransactions_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *TransactionClient_FetchTransactions_Call) RunAndReturn(run func(ctx context.Context, userID int) ([]domain.Transaction, error)) *TransactionClient_FetchTransactions_Call {
+func (_c *TransactionClient_StreamTransactions_Call) RunAndReturn(run func(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error) error) *TransactionClient_StreamTransactions_Call {
 	_c.Call.Return(run)
 	return _c
 }
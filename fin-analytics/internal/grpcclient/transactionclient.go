@@ -6,5 +6,10 @@ import (
 )
 
 type TransactionClient interface {
-	FetchTransactions(ctx context.Context, userID int) ([]domain.Transaction, error)
+	// StreamTransactions passes the user's transactions to fn one batch at
+	// a time, newest first, and stops at the first error fn returns. The
+	// batches are one snapshot, at the user's version they carry; a user
+	// without transactions gets one empty batch. The version is at least
+	// minVersion, so the snapshot includes the write that produced it.
+	StreamTransactions(ctx context.Context, userID int, minVersion int64, fn func(domain.TransactionBatch) error) error
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fin-analytics/internal/domain"
@@ -13,7 +14,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"fin-analytics/internal/metrics"
 	"fin-analytics/internal/swagger"
+	"fin-shared/health"
+	"fin-shared/logging"
+	"fin-shared/tracing"
 )
 
 type AnalyticsService interface {
@@ -23,66 +28,74 @@ type AnalyticsService interface {
 
 type Server struct {
 	service AnalyticsService
+	ready   *health.Checker
+	metrics *metrics.Metrics
 	router  *chi.Mux
 	server  *stdhttp.Server
 }
 
-func NewServer(service AnalyticsService) *Server {
+// NewServer serves plain HTTP when tlsConfig is nil.
+func NewServer(service AnalyticsService, ready *health.Checker, tlsConfig *tls.Config, m *metrics.Metrics) *Server {
 	router := chi.NewRouter()
+	router.Use(tracing.HTTPMiddleware)
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
-	router.Use(middleware.Logger)
+	router.Use(logging.HTTPMiddleware)
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.Timeout(60 * time.Second))
+	router.Use(m.HTTPMiddleware)
 
 	s := &Server{
 		service: service,
+		ready:   ready,
+		metrics: m,
 		router:  router,
+		server:  &stdhttp.Server{Handler: router, TLSConfig: tlsConfig},
 	}
 	s.routes()
 	return s
 }
 
 func (s *Server) routes() {
-	s.router.Get("/healthz", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
-		writeJSON(w, stdhttp.StatusOK, map[string]string{"status": "ok"})
-	})
+	s.router.Method(stdhttp.MethodGet, "/healthz", health.LivenessHandler())
+	s.router.Method(stdhttp.MethodGet, "/livez", health.LivenessHandler())
+	s.router.Method(stdhttp.MethodGet, "/readyz", s.ready.Handler())
+
+	s.router.Method(stdhttp.MethodGet, "/metrics", s.metrics.Handler())
 
 	s.router.Route("/swagger", func(r chi.Router) {
 		r.Get("/", swagger.UIHandler("/swagger/spec"))
 		r.Get("/spec", swagger.SpecHandler(swagger.FinAnalyticsSpec()))
 	})
 
-	s.router.Get("/v1/users/{userID}/stats", s.handleGetStats)
+	s.router.With(logging.UserIDFromURL).Get("/v1/users/{userID}/stats", s.handleGetStats)
 }
 
-func (s *Server) Start(ctx context.Context, addr string) error {
-	s.server = &stdhttp.Server{
-		Addr:    addr,
-		Handler: s.router,
+// Start serves on addr until Stop is called. Calling Stop first makes
+// Start return immediately.
+func (s *Server) Start(addr string) error {
+	s.server.Addr = addr
+	var err error
+	if s.server.TLSConfig != nil {
+		// The certificate comes from TLSConfig, not from files.
+		err = s.server.ListenAndServeTLS("", "")
+	} else {
+		err = s.server.ListenAndServe()
 	}
-
-	errCh := make(chan error, 1)
-	go func() {
-		errCh <- s.server.ListenAndServe()
-	}()
-
-	select {
-	case <-ctx.Done():
-		return s.Stop(context.Background())
-	case err := <-errCh:
-		if errors.Is(err, stdhttp.ErrServerClosed) {
-			return nil
-		}
+	if !errors.Is(err, stdhttp.ErrServerClosed) {
 		return err
 	}
+	return nil
 }
 
+// Stop closes the listener and waits for in-flight requests until ctx
+// expires, then drops the connections that are still open.
 func (s *Server) Stop(ctx context.Context) error {
-	if s.server == nil {
-		return nil
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return err
 	}
-	return s.server.Shutdown(ctx)
+	return nil
 }
 
 func (s *Server) handleGetStats(w stdhttp.ResponseWriter, r *stdhttp.Request) {
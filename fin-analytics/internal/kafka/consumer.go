@@ -2,9 +2,21 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"fin-analytics/internal/metrics"
+	"fin-shared/logging"
 )
 
 type MessageHandler func(ctx context.Context, message *sarama.ConsumerMessage) error
@@ -13,9 +25,33 @@ type Consumer struct {
 	group   sarama.ConsumerGroup
 	topics  []string
 	handler MessageHandler
+	metrics *metrics.Metrics
+	members *membership
+
+	started  atomic.Bool
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
 }
 
-func NewConsumer(brokers []string, groupID string, topics []string, handler MessageHandler) (*Consumer, error) {
+// membership tracks the consumer group session between Setup and Cleanup,
+// i.e. while this instance owns partitions.
+type membership struct {
+	mu         sync.RWMutex
+	active     bool
+	memberID   string
+	generation int32
+	claims     map[string][]int32
+}
+
+// Membership describes the current consumer group session.
+type Membership struct {
+	MemberID   string             `json:"member_id"`
+	Generation int32              `json:"generation"`
+	Claims     map[string][]int32 `json:"claims"`
+}
+
+func NewConsumer(brokers []string, groupID string, topics []string, handler MessageHandler, m *metrics.Metrics) (*Consumer, error) {
 	cfg := sarama.NewConfig()
 	cfg.Consumer.Return.Errors = true
 	cfg.Version = sarama.V3_5_0_0
@@ -29,40 +65,162 @@ func NewConsumer(brokers []string, groupID string, topics []string, handler Mess
 		group:   group,
 		topics:  topics,
 		handler: handler,
+		metrics: m,
+		members: &membership{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
 	}, nil
 }
 
-func (c *Consumer) Start(ctx context.Context) error {
+// Start consumes until Stop is called.
+func (c *Consumer) Start() error {
+	if !c.started.CompareAndSwap(false, true) {
+		return errors.New("consumer already started")
+	}
+	defer close(c.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		for err := range c.group.Errors() {
+			slog.Warn("Consumer group error", "error", err)
+		}
+	}()
+
+	handler := consumerGroupHandler{handler: c.handler, metrics: c.metrics, members: c.members}
 	for {
-		if err := c.group.Consume(ctx, c.topics, consumerGroupHandler{handler: c.handler}); err != nil {
+		if err := c.group.Consume(ctx, c.topics, handler); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
 			return fmt.Errorf("consume: %w", err)
 		}
 		if ctx.Err() != nil {
-			return ctx.Err()
+			return nil
 		}
 	}
 }
 
-func (c *Consumer) Close() error {
-	if c.group == nil {
-		return nil
+// Ping reports the group session while this instance is a member of the
+// consumer group, and an error between sessions (before the first
+// rebalance, during a rebalance or after the group was closed).
+func (c *Consumer) Ping(_ context.Context) (any, error) {
+	c.members.mu.RLock()
+	defer c.members.mu.RUnlock()
+
+	if !c.members.active {
+		return nil, fmt.Errorf("not a member of the consumer group")
 	}
-	return c.group.Close()
+	return Membership{
+		MemberID:   c.members.memberID,
+		Generation: c.members.generation,
+		Claims:     c.members.claims,
+	}, nil
+}
+
+// Stop ends the group session, waits until the message being processed is
+// handled and marked, and closes the group, which commits the marked
+// offsets. If ctx expires first the group is closed anyway and the
+// unfinished message is redelivered after the rebalance.
+func (c *Consumer) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stop) })
+
+	var err error
+	if c.started.Load() {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	return errors.Join(err, c.group.Close())
 }
 
 type consumerGroupHandler struct {
 	handler MessageHandler
+	metrics *metrics.Metrics
+	members *membership
+}
+
+func (h consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.members.mu.Lock()
+	defer h.members.mu.Unlock()
+
+	h.members.active = true
+	h.members.memberID = session.MemberID()
+	h.members.generation = session.GenerationID()
+	h.members.claims = session.Claims()
+	return nil
 }
 
-func (consumerGroupHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
-func (consumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
+func (h consumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
+	h.members.mu.Lock()
+	defer h.members.mu.Unlock()
+
+	h.members.active = false
+	h.members.claims = nil
+	return nil
+}
 
+// ConsumeClaim stops taking messages as soon as the session ends (shutdown
+// or rebalance), but the message already taken is handled without the
+// session's cancellation and marked, so its offset is part of the final
+// commit and the next owner of the partition does not process it again.
 func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for msg := range claim.Messages() {
-		if err := h.handler(session.Context(), msg); err != nil {
-			return err
+	ctx := context.WithoutCancel(session.Context())
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case msg, ok := <-claim.Messages():
+			// select picks at random when both are ready; never start a
+			// new message once the session is over.
+			if !ok || session.Context().Err() != nil {
+				return nil
+			}
+			h.metrics.SetKafkaLag(msg.Topic, msg.Partition, claim.HighWaterMarkOffset()-msg.Offset-1)
+
+			start := time.Now()
+			err := h.handle(ctx, msg)
+			h.metrics.ObserveKafkaMessage(msg.Topic, start, err)
+			if err != nil {
+				return err
+			}
+			session.MarkMessage(msg, "")
 		}
-		session.MarkMessage(msg, "")
+	}
+}
+
+// handle runs the handler in a consumer span that continues the trace the
+// producer propagated in the message headers.
+func (h consumerGroupHandler) handle(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+	ctx, span := otel.Tracer("fin-analytics/kafka").Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int("messaging.kafka.destination.partition", int(msg.Partition)),
+			attribute.Int64("messaging.kafka.message.offset", msg.Offset),
+		),
+	)
+	defer span.End()
+
+	ctx = logging.With(ctx, "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset)
+
+	if err := h.handler(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logging.FromContext(ctx).Error("Failed to process message", "error", err)
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHandleContinuesProducerTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	msg := &sarama.ConsumerMessage{
+		Topic:     "user-transactions",
+		Partition: 1,
+		Offset:    42,
+		Headers: []*sarama.RecordHeader{{
+			Key:   []byte("traceparent"),
+			Value: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+		}},
+	}
+
+	var handlerSpan trace.SpanContext
+	h := consumerGroupHandler{handler: func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	}}
+	require.NoError(t, h.handle(context.Background(), msg))
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerSpan.TraceID().String())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "user-transactions process", spans[0].Name)
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+}
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+}
+
+func (fakeSession) MemberID() string    { return "member-1" }
+func (fakeSession) GenerationID() int32 { return 3 }
+func (fakeSession) Claims() map[string][]int32 {
+	return map[string][]int32{"user-transactions": {0, 1}}
+}
+func (fakeSession) Context() context.Context { return context.Background() }
+
+func TestPingReflectsGroupMembership(t *testing.T) {
+	c := &Consumer{members: &membership{}}
+	h := consumerGroupHandler{members: c.members}
+
+	_, err := c.Ping(context.Background())
+	assert.Error(t, err)
+
+	require.NoError(t, h.Setup(fakeSession{}))
+	details, err := c.Ping(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Membership{
+		MemberID:   "member-1",
+		Generation: 3,
+		Claims:     map[string][]int32{"user-transactions": {0, 1}},
+	}, details)
+
+	require.NoError(t, h.Cleanup(fakeSession{}))
+	_, err = c.Ping(context.Background())
+	assert.Error(t, err)
+}
+
+type drainSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *drainSession) Context() context.Context { return s.ctx }
+func (s *drainSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+func (c fakeClaim) HighWaterMarkOffset() int64               { return 10 }
+
+func TestConsumeClaimFinishesInFlightMessageOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &drainSession{ctx: ctx}
+	claim := fakeClaim{messages: make(chan *sarama.ConsumerMessage, 2)}
+	claim.messages <- &sarama.ConsumerMessage{Topic: "user-transactions", Offset: 7}
+	claim.messages <- &sarama.ConsumerMessage{Topic: "user-transactions", Offset: 8}
+
+	var handled []int64
+	h := consumerGroupHandler{handler: func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		// Shutdown starts while the first message is being processed.
+		cancel()
+		handled = append(handled, msg.Offset)
+		return ctx.Err()
+	}}
+
+	require.NoError(t, h.ConsumeClaim(session, claim))
+	assert.Equal(t, []int64{7}, handled)
+	assert.Equal(t, []int64{7}, session.marked)
+}
@@ -0,0 +1,220 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"fin-shared/servermetrics"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "fin_analytics"
+
+// Metrics owns the registry the service exposes on /metrics. Tests scrape
+// Registry directly.
+//
+// All Observe methods are safe to call on a nil *Metrics.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	server        *servermetrics.Server
+	grpcRequests  *prometheus.HistogramVec
+	kafkaMessages *prometheus.HistogramVec
+	kafkaLag      *prometheus.GaugeVec
+	cacheRequests *prometheus.CounterVec
+	breakerOpen   prometheus.Gauge
+	dbQueries     *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		grpcRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_handling_seconds",
+			Help:      "Outgoing gRPC call latency by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		kafkaMessages: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kafka_message_processing_seconds",
+			Help:      "Time spent handling a consumed Kafka message by topic and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"topic", "result"}),
+		kafkaLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "kafka_consumer_lag",
+			Help:      "Messages between the last consumed offset and the partition high water mark.",
+		}, []string{"topic", "partition"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Stats cache lookups by result (local_hit, hit, miss, stale, error).",
+		}, []string{"result"}),
+		breakerOpen: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_breaker_open",
+			Help:      "1 while the fin-api circuit breaker is open, 0 otherwise.",
+		}),
+		dbQueries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Analytics store query latency by operation and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "result"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcRequests,
+		m.kafkaMessages,
+		m.kafkaLag,
+		m.cacheRequests,
+		m.breakerOpen,
+		m.dbQueries,
+	)
+	m.server = servermetrics.New(namespace, m.Registry)
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+func (m *Metrics) HTTPMiddleware(next http.Handler) http.Handler {
+	return m.served().HTTPMiddleware(next)
+}
+
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if m != nil {
+			m.grpcRequests.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		}
+		return err
+	}
+}
+
+// StreamClientInterceptor times outgoing streams until the first error
+// from RecvMsg; io.EOF counts as OK.
+func (m *Metrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if m == nil {
+			return stream, err
+		}
+		if err != nil {
+			m.grpcRequests.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+			return nil, err
+		}
+		return &observedStream{ClientStream: stream, observe: func(err error) {
+			m.grpcRequests.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		}}, nil
+	}
+}
+
+type observedStream struct {
+	grpc.ClientStream
+	once    sync.Once
+	observe func(error)
+}
+
+func (s *observedStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				s.observe(nil)
+				return
+			}
+			s.observe(err)
+		})
+	}
+	return err
+}
+
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return m.served().UnaryServerInterceptor()
+}
+
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return m.served().StreamServerInterceptor()
+}
+
+func (m *Metrics) served() *servermetrics.Server {
+	if m == nil {
+		return nil
+	}
+	return m.server
+}
+
+func (m *Metrics) ObserveKafkaMessage(topic string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.kafkaMessages.WithLabelValues(topic, result).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) SetKafkaLag(topic string, partition int32, lag int64) {
+	if m == nil {
+		return
+	}
+	m.kafkaLag.WithLabelValues(topic, strconv.Itoa(int(partition))).Set(float64(lag))
+}
+
+func (m *Metrics) CacheHit()   { m.cacheResult("hit") }
+func (m *Metrics) CacheMiss()  { m.cacheResult("miss") }
+func (m *Metrics) CacheError() { m.cacheResult("error") }
+
+// CacheLocalHit counts stats served from the in-memory tier, without a
+// Redis round-trip.
+func (m *Metrics) CacheLocalHit() { m.cacheResult("local_hit") }
+
+// CacheStale counts stale stats served because fin-api was unavailable.
+func (m *Metrics) CacheStale() { m.cacheResult("stale") }
+
+func (m *Metrics) cacheResult(result string) {
+	if m == nil {
+		return
+	}
+	m.cacheRequests.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) SetBreakerOpen(open bool) {
+	if m == nil {
+		return
+	}
+	if open {
+		m.breakerOpen.Set(1)
+	} else {
+		m.breakerOpen.Set(0)
+	}
+}
+
+func (m *Metrics) ObserveDBQuery(operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.dbQueries.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPMiddlewareUsesRoutePattern(t *testing.T) {
+	m := New()
+	router := chi.NewRouter()
+	router.Use(m.HTTPMiddleware)
+	router.Get("/v1/users/{userID}/stats", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/1/stats", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/2/stats", nil))
+
+	assert.Contains(t, scrape(t, m), `fin_analytics_http_request_duration_seconds_count{method="GET",route="/v1/users/{userID}/stats",status="200"} 2`)
+}
+
+func TestUnaryClientInterceptorRecordsCode(t *testing.T) {
+	m := New()
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.DeadlineExceeded, "slow")
+	}
+
+	err := m.UnaryClientInterceptor()(context.Background(), "/fintrack.TransactionService/GetUserTransactions", nil, nil, nil, invoker)
+	assert.Error(t, err)
+
+	assert.Contains(t, scrape(t, m), `fin_analytics_grpc_client_handling_seconds_count{code="DeadlineExceeded",method="/fintrack.TransactionService/GetUserTransactions"} 1`)
+}
+
+func TestServerInterceptorsRecordCode(t *testing.T) {
+	m := New()
+	unary := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad interval")
+	}
+	stream := func(srv any, ss grpc.ServerStream) error { return nil }
+
+	_, err := m.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/fintrack.v1.AnalyticsService/GetTimeSeries"}, unary)
+	assert.Error(t, err)
+	assert.NoError(t, m.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/fintrack.v1.AnalyticsService/WatchStats"}, stream))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `fin_analytics_grpc_server_handling_seconds_count{code="InvalidArgument",method="/fintrack.v1.AnalyticsService/GetTimeSeries"} 1`)
+	assert.Contains(t, body, `fin_analytics_grpc_server_handling_seconds_count{code="OK",method="/fintrack.v1.AnalyticsService/WatchStats"} 1`)
+}
+
+func TestKafkaAndCacheMetrics(t *testing.T) {
+	m := New()
+	m.ObserveKafkaMessage("transactions", time.Now(), nil)
+	m.ObserveKafkaMessage("transactions", time.Now(), errors.New("bad payload"))
+	m.SetKafkaLag("transactions", 0, 42)
+	m.CacheHit()
+	m.CacheMiss()
+	m.CacheMiss()
+	m.ObserveDBQuery("save_aggregates", time.Now(), nil)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `fin_analytics_kafka_message_processing_seconds_count{result="error",topic="transactions"} 1`)
+	assert.Contains(t, body, `fin_analytics_kafka_consumer_lag{partition="0",topic="transactions"} 42`)
+	assert.Contains(t, body, `fin_analytics_db_query_duration_seconds_count{operation="save_aggregates",result="ok"} 1`)
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("hit")))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.CacheHit()
+	m.SetKafkaLag("transactions", 0, 1)
+	m.ObserveKafkaMessage("transactions", time.Now(), nil)
+	m.ObserveDBQuery("load_aggregates", time.Now(), nil)
+
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	assert.NoError(t, m.UnaryClientInterceptor()(context.Background(), "/m", nil, nil, nil, invoker))
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return strings.TrimSpace(rec.Body.String())
+}
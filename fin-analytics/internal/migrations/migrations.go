@@ -0,0 +1,205 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey is the pg_advisory_lock key held while the analytics schema is
+// migrated. It differs from the fin-api key, so the store can share a
+// database with a shard.
+const lockKey int64 = 0x66696e616e616c
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var filePattern = regexp.MustCompile(`^(\d+)_(\w+)\.sql$`)
+
+type Migration struct {
+	Version  int
+	Name     string
+	Checksum string
+	tmpl     *template.Template
+}
+
+func (m Migration) render(schema string) (string, error) {
+	var buf bytes.Buffer
+	if err := m.tmpl.Execute(&buf, struct{ Schema string }{Schema: schema}); err != nil {
+		return "", fmt.Errorf("render migration %d: %w", m.Version, err)
+	}
+	return buf.String(), nil
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		match := filePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		content, err := files.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+		tmpl, err := template.New(entry.Name()).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("parse migration %s: %w", entry.Name(), err)
+		}
+
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     match[2],
+			Checksum: hex.EncodeToString(sum[:]),
+			tmpl:     tmpl,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	schema     string
+	migrations []Migration
+}
+
+func New(pool *pgxpool.Pool, schema string) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, schema: schema, migrations: migrations}, nil
+}
+
+// Up creates the analytics schema and applies pending migrations under an
+// advisory lock, so that fin-analytics replicas starting together don't
+// race.
+func (m *Migrator) Up(ctx context.Context) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}()
+
+	if err := m.migrate(ctx, conn); err != nil {
+		return fmt.Errorf("migrate %s: %w", m.schema, err)
+	}
+	return nil
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *pgxpool.Conn) error {
+	_, err := conn.Exec(ctx, fmt.Sprintf(`
+		CREATE SCHEMA IF NOT EXISTS %[1]s;
+		CREATE TABLE IF NOT EXISTS %[1]s.schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`, m.schema))
+	if err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if checksum, ok := applied[migration.Version]; ok {
+			if checksum != migration.Checksum {
+				return fmt.Errorf("migration %d_%s: checksum mismatch", migration.Version, migration.Name)
+			}
+			continue
+		}
+
+		query, err := migration.render(m.schema)
+		if err != nil {
+			return err
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, query); err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Exec(ctx, fmt.Sprintf(`
+				INSERT INTO %s.schema_migrations (version, name, checksum)
+				VALUES ($1, $2, $3)
+			`, m.schema), migration.Version, migration.Name, migration.Checksum)
+			if err != nil {
+				return fmt.Errorf("record migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *pgxpool.Conn) (map[int]string, error) {
+	rows, err := conn.Query(ctx, fmt.Sprintf(`
+		SELECT version, checksum
+		FROM %s.schema_migrations
+	`, m.schema))
+	if err != nil {
+		return nil, fmt.Errorf("query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]string)
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[version] = checksum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return applied, nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrdersMigrations(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.NotEmpty(t, migration.Name)
+		assert.Len(t, migration.Checksum, 64)
+		if i > 0 {
+			assert.Greater(t, migration.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestRenderUsesSchema(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+
+	query, err := migrations[0].render("analytics")
+	require.NoError(t, err)
+	assert.Contains(t, query, "analytics.user_totals")
+	assert.Contains(t, query, "analytics.category_monthly")
+	assert.NotContains(t, query, "{{")
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS {{.Schema}}.user_totals (
+    user_id INTEGER PRIMARY KEY,
+    income_total NUMERIC(16,2) NOT NULL,
+    income_count INTEGER NOT NULL,
+    expense_total NUMERIC(16,2) NOT NULL,
+    expense_count INTEGER NOT NULL,
+    -- The version of the last applied event; older events are skipped.
+    event_version BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS {{.Schema}}.category_monthly (
+    user_id INTEGER NOT NULL REFERENCES {{.Schema}}.user_totals (user_id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('income', 'expense')),
+    category TEXT NOT NULL,
+    total NUMERIC(16,2) NOT NULL,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (user_id, month, type, category)
+);
@@ -0,0 +1,14 @@
+ALTER TABLE {{.Schema}}.user_totals
+    ADD COLUMN IF NOT EXISTS income_min NUMERIC(16,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS income_max NUMERIC(16,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS expense_min NUMERIC(16,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS expense_max NUMERIC(16,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE {{.Schema}}.category_monthly
+    ADD COLUMN IF NOT EXISTS min NUMERIC(16,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max NUMERIC(16,2) NOT NULL DEFAULT 0;
+
+-- Stored aggregates have no min or max yet, and changes would be applied
+-- on top of the zeros. Dropping them makes the next load rebuild them
+-- from fin-api.
+TRUNCATE {{.Schema}}.user_totals CASCADE;
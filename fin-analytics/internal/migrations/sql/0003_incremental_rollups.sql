@@ -0,0 +1,16 @@
+-- Per-category totals over all months, so that stats can be derived
+-- without reading every monthly rollup of the user.
+CREATE TABLE IF NOT EXISTS {{.Schema}}.category_totals (
+    user_id INTEGER NOT NULL REFERENCES {{.Schema}}.user_totals (user_id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('income', 'expense')),
+    category TEXT NOT NULL,
+    total NUMERIC(16,2) NOT NULL,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (user_id, type, category)
+);
+
+INSERT INTO {{.Schema}}.category_totals (user_id, type, category, total, count)
+SELECT user_id, type, category, SUM(total), SUM(count)
+FROM {{.Schema}}.category_monthly
+GROUP BY user_id, type, category
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,309 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"fin-analytics/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewAnalyticsRepository creates a new instance of AnalyticsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnalyticsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AnalyticsRepository {
+	mock := &AnalyticsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AnalyticsRepository is an autogenerated mock type for the AnalyticsRepository type
+type AnalyticsRepository struct {
+	mock.Mock
+}
+
+type AnalyticsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AnalyticsRepository) EXPECT() *AnalyticsRepository_Expecter {
+	return &AnalyticsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Load provides a mock function for the type AnalyticsRepository
+func (_mock *AnalyticsRepository) Load(ctx context.Context, userID int) (domain.Aggregates, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 domain.Aggregates
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (domain.Aggregates, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) domain.Aggregates); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Aggregates)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AnalyticsRepository_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type AnalyticsRepository_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *AnalyticsRepository_Expecter) Load(ctx interface{}, userID interface{}) *AnalyticsRepository_Load_Call {
+	return &AnalyticsRepository_Load_Call{Call: _e.mock.On("Load", ctx, userID)}
+}
+
+func (_c *AnalyticsRepository_Load_Call) Run(run func(ctx context.Context, userID int)) *AnalyticsRepository_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *AnalyticsRepository_Load_Call) Return(aggregates domain.Aggregates, err error) *AnalyticsRepository_Load_Call {
+	_c.Call.Return(aggregates, err)
+	return _c
+}
+
+func (_c *AnalyticsRepository_Load_Call) RunAndReturn(run func(ctx context.Context, userID int) (domain.Aggregates, error)) *AnalyticsRepository_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadMonths provides a mock function for the type AnalyticsRepository
+func (_mock *AnalyticsRepository) LoadMonths(ctx context.Context, userID int, keys []domain.MonthKey) (domain.Aggregates, error) {
+	ret := _mock.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadMonths")
+	}
+
+	var r0 domain.Aggregates
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []domain.MonthKey) (domain.Aggregates, error)); ok {
+		return returnFunc(ctx, userID, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []domain.MonthKey) domain.Aggregates); ok {
+		r0 = returnFunc(ctx, userID, keys)
+	} else {
+		r0 = ret.Get(0).(domain.Aggregates)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, []domain.MonthKey) error); ok {
+		r1 = returnFunc(ctx, userID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AnalyticsRepository_LoadMonths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadMonths'
+type AnalyticsRepository_LoadMonths_Call struct {
+	*mock.Call
+}
+
+// LoadMonths is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - keys []domain.MonthKey
+func (_e *AnalyticsRepository_Expecter) LoadMonths(ctx interface{}, userID interface{}, keys interface{}) *AnalyticsRepository_LoadMonths_Call {
+	return &AnalyticsRepository_LoadMonths_Call{Call: _e.mock.On("LoadMonths", ctx, userID, keys)}
+}
+
+func (_c *AnalyticsRepository_LoadMonths_Call) Run(run func(ctx context.Context, userID int, keys []domain.MonthKey)) *AnalyticsRepository_LoadMonths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []domain.MonthKey
+		if args[2] != nil {
+			arg2 = args[2].([]domain.MonthKey)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *AnalyticsRepository_LoadMonths_Call) Return(aggregates domain.Aggregates, err error) *AnalyticsRepository_LoadMonths_Call {
+	_c.Call.Return(aggregates, err)
+	return _c
+}
+
+func (_c *AnalyticsRepository_LoadMonths_Call) RunAndReturn(run func(ctx context.Context, userID int, keys []domain.MonthKey) (domain.Aggregates, error)) *AnalyticsRepository_LoadMonths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type AnalyticsRepository
+func (_mock *AnalyticsRepository) Save(ctx context.Context, agg domain.Aggregates) (bool, error) {
+	ret := _mock.Called(ctx, agg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Aggregates) (bool, error)); ok {
+		return returnFunc(ctx, agg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Aggregates) bool); ok {
+		r0 = returnFunc(ctx, agg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Aggregates) error); ok {
+		r1 = returnFunc(ctx, agg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AnalyticsRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type AnalyticsRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - agg domain.Aggregates
+func (_e *AnalyticsRepository_Expecter) Save(ctx interface{}, agg interface{}) *AnalyticsRepository_Save_Call {
+	return &AnalyticsRepository_Save_Call{Call: _e.mock.On("Save", ctx, agg)}
+}
+
+func (_c *AnalyticsRepository_Save_Call) Run(run func(ctx context.Context, agg domain.Aggregates)) *AnalyticsRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Aggregates
+		if args[1] != nil {
+			arg1 = args[1].(domain.Aggregates)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *AnalyticsRepository_Save_Call) Return(b bool, err error) *AnalyticsRepository_Save_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *AnalyticsRepository_Save_Call) RunAndReturn(run func(ctx context.Context, agg domain.Aggregates) (bool, error)) *AnalyticsRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDelta provides a mock function for the type AnalyticsRepository
+func (_mock *AnalyticsRepository) SaveDelta(ctx context.Context, delta domain.AggregatesDelta) (bool, error) {
+	ret := _mock.Called(ctx, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDelta")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AggregatesDelta) (bool, error)); ok {
+		return returnFunc(ctx, delta)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AggregatesDelta) bool); ok {
+		r0 = returnFunc(ctx, delta)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AggregatesDelta) error); ok {
+		r1 = returnFunc(ctx, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AnalyticsRepository_SaveDelta_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDelta'
+type AnalyticsRepository_SaveDelta_Call struct {
+	*mock.Call
+}
+
+// SaveDelta is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delta domain.AggregatesDelta
+func (_e *AnalyticsRepository_Expecter) SaveDelta(ctx interface{}, delta interface{}) *AnalyticsRepository_SaveDelta_Call {
+	return &AnalyticsRepository_SaveDelta_Call{Call: _e.mock.On("SaveDelta", ctx, delta)}
+}
+
+func (_c *AnalyticsRepository_SaveDelta_Call) Run(run func(ctx context.Context, delta domain.AggregatesDelta)) *AnalyticsRepository_SaveDelta_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.AggregatesDelta
+		if args[1] != nil {
+			arg1 = args[1].(domain.AggregatesDelta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *AnalyticsRepository_SaveDelta_Call) Return(b bool, err error) *AnalyticsRepository_SaveDelta_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *AnalyticsRepository_SaveDelta_Call) RunAndReturn(run func(ctx context.Context, delta domain.AggregatesDelta) (bool, error)) *AnalyticsRepository_SaveDelta_Call {
+	_c.Call.Return(run)
+	return _c
+}
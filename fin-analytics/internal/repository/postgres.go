@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"fin-analytics/internal/domain"
+	"fin-analytics/internal/metrics"
+	"fin-shared/logging"
+)
+
+var tracer = otel.Tracer("fin-analytics/repository")
+
+type PostgresAnalyticsRepository struct {
+	pool    *pgxpool.Pool
+	schema  string
+	metrics *metrics.Metrics
+}
+
+func NewAnalyticsRepository(pool *pgxpool.Pool, schema string, m *metrics.Metrics) *PostgresAnalyticsRepository {
+	return &PostgresAnalyticsRepository{pool: pool, schema: schema, metrics: m}
+}
+
+// startQuery opens a client span for operation and returns the function
+// that ends it, records the latency and logs failures.
+func (r *PostgresAnalyticsRepository) startQuery(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "postgres."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.namespace", r.schema),
+		),
+	)
+
+	return ctx, func(err error) {
+		if errors.Is(err, domain.ErrAggregatesNotFound) {
+			err = nil
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logging.FromContext(ctx).Error("Query failed", "operation", operation, "error", err)
+		}
+		span.End()
+		r.metrics.ObserveDBQuery(operation, start, err)
+	}
+}
+
+func (r *PostgresAnalyticsRepository) Load(ctx context.Context, userID int) (agg domain.Aggregates, err error) {
+	ctx, done := r.startQuery(ctx, "load_aggregates")
+	defer func() { done(err) }()
+
+	return r.load(ctx, userID, func(tx pgx.Tx) (pgx.Rows, error) {
+		return tx.Query(ctx, fmt.Sprintf(`
+			SELECT month, type, category, total, count, min, max
+			FROM %s.category_monthly
+			WHERE user_id = $1
+			ORDER BY month, type, category
+		`, r.schema), userID)
+	})
+}
+
+func (r *PostgresAnalyticsRepository) LoadMonths(ctx context.Context, userID int, keys []domain.MonthKey) (agg domain.Aggregates, err error) {
+	ctx, done := r.startQuery(ctx, "load_aggregate_months")
+	defer func() { done(err) }()
+
+	months := make([]time.Time, len(keys))
+	types := make([]string, len(keys))
+	categories := make([]string, len(keys))
+	for i, key := range keys {
+		months[i], types[i], categories[i] = key.Month, string(key.Type), key.Category
+	}
+	return r.load(ctx, userID, func(tx pgx.Tx) (pgx.Rows, error) {
+		return tx.Query(ctx, fmt.Sprintf(`
+			SELECT month, type, category, total, count, min, max
+			FROM %s.category_monthly
+			WHERE user_id = $1
+			  AND (month, type, category) IN (
+			      SELECT * FROM unnest($2::date[], $3::text[], $4::text[])
+			  )
+			ORDER BY month, type, category
+		`, r.schema), userID, months, types, categories)
+	})
+}
+
+// load reads the user's totals, category totals and the rollups queryMonths
+// selects in one snapshot, so that a concurrent save can't be seen half
+// applied.
+func (r *PostgresAnalyticsRepository) load(ctx context.Context, userID int, queryMonths func(pgx.Tx) (pgx.Rows, error)) (domain.Aggregates, error) {
+	agg := domain.Aggregates{UserID: userID}
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, fmt.Sprintf(`
+			SELECT income_total, income_count, income_min, income_max,
+			       expense_total, expense_count, expense_min, expense_max, event_version
+			FROM %s.user_totals
+			WHERE user_id = $1
+		`, r.schema), userID).Scan(
+			&agg.Income.Sum, &agg.Income.Count, &agg.Income.Min, &agg.Income.Max,
+			&agg.Expense.Sum, &agg.Expense.Count, &agg.Expense.Min, &agg.Expense.Max, &agg.Version,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrAggregatesNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("query user totals: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(`
+			SELECT type, category, total, count
+			FROM %s.category_totals
+			WHERE user_id = $1
+			ORDER BY type, category
+		`, r.schema), userID)
+		if err != nil {
+			return fmt.Errorf("query category totals: %w", err)
+		}
+		agg.Categories, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.CategoryTotal, error) {
+			var category domain.CategoryTotal
+			err := row.Scan(&category.Type, &category.Category, &category.Sum, &category.Count)
+			return category, err
+		})
+		if err != nil {
+			return fmt.Errorf("scan category totals: %w", err)
+		}
+
+		rows, err = queryMonths(tx)
+		if err != nil {
+			return fmt.Errorf("query category rollups: %w", err)
+		}
+		agg.Months, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.CategoryMonth, error) {
+			var month domain.CategoryMonth
+			err := row.Scan(&month.Month, &month.Type, &month.Category, &month.Sum, &month.Count, &month.Min, &month.Max)
+			return month, err
+		})
+		if err != nil {
+			return fmt.Errorf("scan category rollups: %w", err)
+		}
+		return nil
+	})
+	return agg, err
+}
+
+// Save upserts the totals first: the row lock it takes serialises
+// concurrent saves for the user, and the version check in the upsert
+// decides whether the rollups are replaced.
+func (r *PostgresAnalyticsRepository) Save(ctx context.Context, agg domain.Aggregates) (applied bool, err error) {
+	ctx, done := r.startQuery(ctx, "save_aggregates")
+	defer func() { done(err) }()
+
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		applied, err = r.upsertTotals(ctx, tx, agg.UserID, agg.Income, agg.Expense, agg.Version)
+		if err != nil || !applied {
+			return err
+		}
+
+		for _, table := range []string{"category_totals", "category_monthly"} {
+			if _, err := tx.Exec(ctx, fmt.Sprintf(`
+				DELETE FROM %s.%s
+				WHERE user_id = $1
+			`, r.schema, table), agg.UserID); err != nil {
+				return fmt.Errorf("delete %s: %w", table, err)
+			}
+		}
+
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{r.schema, "category_totals"},
+			[]string{"user_id", "type", "category", "total", "count"},
+			pgx.CopyFromSlice(len(agg.Categories), func(i int) ([]any, error) {
+				category := agg.Categories[i]
+				return []any{agg.UserID, string(category.Type), category.Category, category.Sum, category.Count}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("copy category totals: %w", err)
+		}
+
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{r.schema, "category_monthly"},
+			[]string{"user_id", "month", "type", "category", "total", "count", "min", "max"},
+			pgx.CopyFromSlice(len(agg.Months), func(i int) ([]any, error) {
+				month := agg.Months[i]
+				return []any{agg.UserID, month.Month, string(month.Type), month.Category, month.Sum, month.Count, month.Min, month.Max}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("copy category rollups: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// SaveDelta takes the same lock and version check as Save, then writes
+// only the rows the change touched.
+func (r *PostgresAnalyticsRepository) SaveDelta(ctx context.Context, delta domain.AggregatesDelta) (applied bool, err error) {
+	ctx, done := r.startQuery(ctx, "save_aggregates_delta")
+	defer func() { done(err) }()
+
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		applied, err = r.upsertTotals(ctx, tx, delta.UserID, delta.Income, delta.Expense, delta.Version)
+		if err != nil || !applied {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+		for _, category := range delta.Categories {
+			if category.Count == 0 {
+				batch.Queue(fmt.Sprintf(`
+					DELETE FROM %s.category_totals
+					WHERE user_id = $1 AND type = $2 AND category = $3
+				`, r.schema), delta.UserID, string(category.Type), category.Category)
+				continue
+			}
+			batch.Queue(fmt.Sprintf(`
+				INSERT INTO %s.category_totals (user_id, type, category, total, count)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (user_id, type, category) DO UPDATE SET
+					total = EXCLUDED.total,
+					count = EXCLUDED.count
+			`, r.schema), delta.UserID, string(category.Type), category.Category, category.Sum, category.Count)
+		}
+		for _, month := range delta.Months {
+			if month.Count == 0 {
+				batch.Queue(fmt.Sprintf(`
+					DELETE FROM %s.category_monthly
+					WHERE user_id = $1 AND month = $2 AND type = $3 AND category = $4
+				`, r.schema), delta.UserID, month.Month, string(month.Type), month.Category)
+				continue
+			}
+			batch.Queue(fmt.Sprintf(`
+				INSERT INTO %s.category_monthly (user_id, month, type, category, total, count, min, max)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (user_id, month, type, category) DO UPDATE SET
+					total = EXCLUDED.total,
+					count = EXCLUDED.count,
+					min = EXCLUDED.min,
+					max = EXCLUDED.max
+			`, r.schema), delta.UserID, month.Month, string(month.Type), month.Category, month.Sum, month.Count, month.Min, month.Max)
+		}
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return fmt.Errorf("upsert touched rollups: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// upsertTotals writes the user's totals unless the stored ones reflect a
+// newer version, and reports whether it did.
+func (r *PostgresAnalyticsRepository) upsertTotals(ctx context.Context, tx pgx.Tx, userID int, income, expense domain.Total, version int64) (bool, error) {
+	tag, err := tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO %s.user_totals AS t (
+			user_id, income_total, income_count, income_min, income_max,
+			expense_total, expense_count, expense_min, expense_max, event_version, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			income_total = EXCLUDED.income_total,
+			income_count = EXCLUDED.income_count,
+			income_min = EXCLUDED.income_min,
+			income_max = EXCLUDED.income_max,
+			expense_total = EXCLUDED.expense_total,
+			expense_count = EXCLUDED.expense_count,
+			expense_min = EXCLUDED.expense_min,
+			expense_max = EXCLUDED.expense_max,
+			event_version = EXCLUDED.event_version,
+			updated_at = EXCLUDED.updated_at
+		WHERE t.event_version <= EXCLUDED.event_version
+	`, r.schema),
+		userID, income.Sum, income.Count, income.Min, income.Max,
+		expense.Sum, expense.Count, expense.Min, expense.Max, version,
+	)
+	if err != nil {
+		return false, fmt.Errorf("upsert user totals: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"fin-analytics/internal/domain"
+)
+
+// AnalyticsRepository is the persistent store of precomputed aggregates.
+type AnalyticsRepository interface {
+	// Load returns the user's aggregates, or domain.ErrAggregatesNotFound
+	// if none were saved yet.
+	Load(ctx context.Context, userID int) (domain.Aggregates, error)
+	// LoadMonths is Load with only the rollups at keys, which is all a
+	// single change needs.
+	LoadMonths(ctx context.Context, userID int, keys []domain.MonthKey) (domain.Aggregates, error)
+	// Save replaces the user's aggregates unless the stored ones reflect a
+	// newer version, and reports whether it did.
+	Save(ctx context.Context, agg domain.Aggregates) (bool, error)
+	// SaveDelta writes the totals in delta and upserts its category totals
+	// and rollups, deleting those with a zero Count, unless the stored
+	// aggregates reflect a newer version. It reports whether it did.
+	SaveDelta(ctx context.Context, delta domain.AggregatesDelta) (bool, error)
+}
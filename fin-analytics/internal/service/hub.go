@@ -0,0 +1,54 @@
+package service
+
+import (
+	"sync"
+
+	"fin-analytics/internal/domain"
+)
+
+// hub fans recalculated stats out to WatchStats subscribers. Each
+// subscriber holds only the latest stats: a slow reader skips
+// intermediate updates instead of blocking the Kafka consumer.
+type hub struct {
+	mu   sync.Mutex
+	subs map[int]map[chan domain.FinanceStats]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subs: map[int]map[chan domain.FinanceStats]struct{}{}}
+}
+
+func (h *hub) subscribe(userID int) (<-chan domain.FinanceStats, func()) {
+	ch := make(chan domain.FinanceStats, 1)
+
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = map[chan domain.FinanceStats]struct{}{}
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs[userID], ch)
+			if len(h.subs[userID]) == 0 {
+				delete(h.subs, userID)
+			}
+		})
+	}
+}
+
+func (h *hub) publish(stats domain.FinanceStats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[stats.UserID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- stats
+	}
+}
@@ -3,53 +3,351 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fin-analytics/internal/cache"
 	client "fin-analytics/internal/grpcclient"
+	"fin-analytics/internal/repository"
 	"fin-analytics/internal/statscalculator"
 	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/IBM/sarama"
+	"golang.org/x/sync/singleflight"
 
 	"fin-analytics/internal/domain"
+	"fin-shared/config"
+	"fin-shared/logging"
 )
 
+// loadTimeout bounds a load shared by the callers of loadStats. The
+// fin-api call in it is also bounded by fin_api.grpc_client.timeout.
+const loadTimeout = 30 * time.Second
+
+// Features reports runtime feature toggles.
+type Features interface {
+	Enabled(name string) bool
+}
+
+// Service keeps the aggregates of every user in the analytics store and
+// reads stats through the cache in front of it. fin-api is only asked for
+// the transactions of users the store doesn't know yet and of users whose
+// aggregates the events can't keep up to date.
 type Service struct {
-	cache  cache.StatsCache
-	client client.TransactionClient
+	cache    cache.StatsCache
+	updates  cache.UpdatePublisher
+	store    repository.AnalyticsRepository
+	client   client.TransactionClient
+	features Features
+	hub      *hub
+	// loads shares one fin-api fetch between concurrent requests for the
+	// same user.
+	loads      singleflight.Group
+	refreshing sync.Map
+	softTTL    atomic.Int64
 }
 
-func New(cache cache.StatsCache, client client.TransactionClient) *Service {
+func New(cache cache.StatsCache, updates cache.UpdatePublisher, store repository.AnalyticsRepository, client client.TransactionClient, features Features) *Service {
 	return &Service{
-		cache:  cache,
-		client: client,
+		cache:    cache,
+		updates:  updates,
+		store:    store,
+		client:   client,
+		features: features,
+		hub:      newHub(),
 	}
 }
 
+// SetSoftTTL sets the age after which cached stats are recomputed in the
+// background while still being served. Zero turns the refresh off.
+func (s *Service) SetSoftTTL(ttl time.Duration) {
+	s.softTTL.Store(int64(ttl))
+}
+
 func (s *Service) ProcessKafkaMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
 	var payload domain.TransactionMessage
 	if err := json.Unmarshal(msg.Value, &payload); err != nil {
 		return fmt.Errorf("decode payload: %w", err)
 	}
+	ctx = logging.With(ctx, "user_id", payload.UserID)
 
-	stats := statscalculator.CalculateStats(payload.Transactions)
+	agg, delta, ok, err := s.aggregate(ctx, payload)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if delta != nil {
+			ok, err = s.store.SaveDelta(ctx, *delta)
+		} else {
+			ok, err = s.store.Save(ctx, agg)
+		}
+		if err != nil {
+			return fmt.Errorf("save aggregates: %w", err)
+		}
+	}
+	if !ok {
+		logging.FromContext(ctx).Debug("Skipped outdated event", "version", payload.Version)
+		return nil
+	}
+
+	// The message is redelivered if the cache can't be updated; saving the
+	// same version again is allowed, so the retry reaches the cache.
+	stats := statscalculator.Stats(agg)
 	if err := s.cache.Set(ctx, stats); err != nil {
 		return fmt.Errorf("cache stats: %w", err)
 	}
+	s.hub.publish(stats)
+	// The cache already has the stats; watchers on other replicas get the
+	// next update instead, which is not worth a redelivery.
+	if err := s.updates.Publish(ctx, stats); err != nil {
+		logging.FromContext(ctx).Warn("Failed to publish stats update", "error", err)
+	}
+	logging.FromContext(ctx).Debug("Stats updated", "version", agg.Version)
 	return nil
 }
 
-func (s *Service) GetStats(ctx context.Context, userID int) (domain.FinanceStats, error) {
-	if cached, err := s.cache.Get(ctx, userID); err == nil && cached != nil {
-		return *cached, nil
+// aggregate applies the change in payload to the stored aggregates of the
+// user. ok is false for an event the stored aggregates already count.
+// Only the rollups the change touches are loaded, and delta holds what it
+// changed; delta is nil when the aggregates were rebuilt and must be
+// saved whole. Either way agg has the totals and category totals the
+// stats are derived from.
+//
+// A change is applied only on top of the version right before it. The
+// aggregates are rebuilt from the user's transactions in fin-api, as of
+// the event or later, when that is not possible: the event predates
+// versions, nothing is stored for the user, events were missed, or the
+// change removes a transaction the aggregates don't count or the one that
+// held a min or max.
+func (s *Service) aggregate(ctx context.Context, payload domain.TransactionMessage) (agg domain.Aggregates, delta *domain.AggregatesDelta, ok bool, err error) {
+	rebuild := func(reason string) (domain.Aggregates, *domain.AggregatesDelta, bool, error) {
+		logging.FromContext(ctx).Info("Rebuilding aggregates from fin-api", "reason", reason, "version", payload.Version)
+		agg, err := s.snapshot(ctx, payload.UserID, payload.Version)
+		if err != nil {
+			return domain.Aggregates{}, nil, false, fmt.Errorf("rebuild aggregates: %w", err)
+		}
+		return agg, nil, true, nil
+	}
+	if payload.Change == nil || payload.Version == 0 {
+		return rebuild("event without a versioned change")
 	}
 
-	txs, err := s.client.FetchTransactions(ctx, userID)
+	stored, err := s.store.LoadMonths(ctx, payload.UserID, statscalculator.MonthKeys(*payload.Change))
+	if errors.Is(err, domain.ErrAggregatesNotFound) {
+		return rebuild("no stored aggregates")
+	}
 	if err != nil {
-		return domain.FinanceStats{}, err
+		return domain.Aggregates{}, nil, false, fmt.Errorf("load aggregates: %w", err)
 	}
-	stats := statscalculator.CalculateStats(txs)
-	if err := s.cache.Set(ctx, stats); err != nil {
+	switch {
+	case payload.Version <= stored.Version:
+		return domain.Aggregates{}, nil, false, nil
+	case payload.Version > stored.Version+1:
+		return rebuild(fmt.Sprintf("missed events after version %d", stored.Version))
+	}
+
+	aggregator := statscalculator.NewAggregator(stored)
+	if err := aggregator.Apply(*payload.Change); err != nil {
+		return rebuild(err.Error())
+	}
+	if aggregator.NeedsRecompute() {
+		return rebuild("a min or max was removed")
+	}
+	agg = aggregator.Aggregates()
+	agg.Version = payload.Version
+	changed := aggregator.Delta()
+	changed.Version = payload.Version
+	return agg, &changed, true, nil
+}
+
+func (s *Service) GetStats(ctx context.Context, userID int) (domain.FinanceStats, error) {
+	if s.features.Enabled(config.FeatureStatsCache) {
+		cached, err := s.cache.Get(ctx, userID)
+		if err != nil {
+			logging.FromContext(ctx).Warn("Failed to read stats cache", "error", err)
+		}
+		if cached != nil {
+			if s.needsRefresh(*cached) {
+				s.refresh(ctx, userID)
+			}
+			return *cached, nil
+		}
+	}
+
+	stats, err := s.loadStats(ctx, userID)
+	if err != nil {
+		if stale := s.staleStats(ctx, userID, err); stale != nil {
+			return *stale, nil
+		}
 		return domain.FinanceStats{}, err
 	}
 	return stats, nil
 }
+
+// loadStats derives the user's stats from the stored aggregates and
+// caches them. Callers that arrive while a load for the user is running
+// wait for it and get the same result instead of loading again.
+//
+// The load is not bound to the caller that starts it: it runs under
+// loadTimeout, and a caller that gives up only stops waiting, so the
+// others still get the stats.
+func (s *Service) loadStats(ctx context.Context, userID int) (domain.FinanceStats, error) {
+	shared := context.WithoutCancel(ctx)
+	loaded := s.loads.DoChan(strconv.Itoa(userID), func() (any, error) {
+		ctx, cancel := context.WithTimeout(shared, loadTimeout)
+		defer cancel()
+
+		agg, err := s.loadAggregates(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		stats := statscalculator.Stats(agg)
+		if err := s.cache.Set(ctx, stats); err != nil {
+			logging.FromContext(ctx).Warn("Failed to cache stats", "error", err)
+		}
+		return stats, nil
+	})
+
+	select {
+	case res := <-loaded:
+		if res.Err != nil {
+			return domain.FinanceStats{}, res.Err
+		}
+		return res.Val.(domain.FinanceStats), nil
+	case <-ctx.Done():
+		return domain.FinanceStats{}, ctx.Err()
+	}
+}
+
+// loadAggregates reads the user's aggregates from the store. Users it has
+// none for yet are aggregated from the transactions in fin-api and saved;
+// while the store is failing the aggregates are computed the same way but
+// not saved.
+func (s *Service) loadAggregates(ctx context.Context, userID int) (domain.Aggregates, error) {
+	agg, err := s.store.Load(ctx, userID)
+	if err == nil {
+		return agg, nil
+	}
+	if !errors.Is(err, domain.ErrAggregatesNotFound) {
+		logging.FromContext(ctx).Warn("Failed to load aggregates, recomputing from fin-api", "error", err)
+	}
+
+	// The snapshot carries the version it was read at, so it loses to
+	// events for later writes saved while it is in flight.
+	agg, err = s.snapshot(ctx, userID, 0)
+	if err != nil {
+		return domain.Aggregates{}, err
+	}
+
+	applied, err := s.store.Save(ctx, agg)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to save aggregates", "error", err)
+		return agg, nil
+	}
+	if !applied {
+		// An event saved newer aggregates in the meantime.
+		return s.store.Load(ctx, userID)
+	}
+	return agg, nil
+}
+
+// snapshot aggregates the user's transactions streamed from fin-api, at
+// minVersion or a later version.
+func (s *Service) snapshot(ctx context.Context, userID int, minVersion int64) (domain.Aggregates, error) {
+	aggregator := statscalculator.NewAggregator(domain.Aggregates{UserID: userID})
+	var version int64
+	err := s.client.StreamTransactions(ctx, userID, minVersion, func(batch domain.TransactionBatch) error {
+		version = batch.Version
+		for _, tx := range batch.Transactions {
+			aggregator.Add(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Aggregates{}, err
+	}
+	agg := aggregator.Aggregates()
+	agg.Version = version
+	return agg, nil
+}
+
+func (s *Service) needsRefresh(stats domain.FinanceStats) bool {
+	softTTL := time.Duration(s.softTTL.Load())
+	return softTTL > 0 && time.Since(stats.GeneratedAt) > softTTL
+}
+
+// refresh reloads the user's stats in a goroutine, unless a refresh for
+// the user is already running. It outlives the request that noticed the
+// old stats, so ctx is only used for its values.
+func (s *Service) refresh(ctx context.Context, userID int) {
+	if _, running := s.refreshing.LoadOrStore(userID, struct{}{}); running {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer s.refreshing.Delete(userID)
+		if _, err := s.loadStats(ctx, userID); err != nil {
+			logging.FromContext(ctx).Warn("Failed to refresh stats", "error", err)
+		}
+	}()
+}
+
+// staleStats returns the last stats cached for the user when fetchErr
+// means fin-api is unavailable, so reads degrade to old numbers instead of
+// failing. It returns nil when there is nothing to fall back to.
+func (s *Service) staleStats(ctx context.Context, userID int, fetchErr error) *domain.FinanceStats {
+	if !client.IsUnavailable(fetchErr) {
+		return nil
+	}
+	stale, err := s.cache.GetStale(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to read stale stats", "error", err)
+		return nil
+	}
+	if stale == nil {
+		return nil
+	}
+	logging.FromContext(ctx).Warn("fin-api unavailable, serving stale stats", "error", fetchErr, "generated_at", stale.GeneratedAt)
+	stale.Stale = true
+	return stale
+}
+
+// GetTimeSeries buckets the user's transactions in [from, to) by interval.
+// Monthly series over whole months come from the stored rollups; other
+// series need the transactions from fin-api.
+func (s *Service) GetTimeSeries(ctx context.Context, userID int, interval domain.Interval, from, to time.Time) ([]domain.TimeSeriesPoint, error) {
+	if interval == domain.IntervalMonth && statscalculator.IsMonthStart(from) && statscalculator.IsMonthStart(to) {
+		agg, err := s.loadAggregates(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return statscalculator.MonthlySeries(agg, from, to), nil
+	}
+
+	series := statscalculator.NewSeries(interval, from, to)
+	err := s.client.StreamTransactions(ctx, userID, 0, func(batch domain.TransactionBatch) error {
+		for _, tx := range batch.Transactions {
+			series.Add(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series.Points(), nil
+}
+
+// Watch subscribes to the stats recalculated from Kafka messages for the
+// user, on this replica or, through Deliver, on another one. The channel
+// keeps only the latest update; call cancel to unsubscribe.
+func (s *Service) Watch(userID int) (<-chan domain.FinanceStats, func()) {
+	return s.hub.subscribe(userID)
+}
+
+// Deliver passes stats recalculated on another replica to the watchers of
+// the user on this one.
+func (s *Service) Deliver(stats domain.FinanceStats) {
+	s.hub.publish(stats)
+}
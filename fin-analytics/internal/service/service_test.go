@@ -5,53 +5,230 @@ import (
 	"encoding/json"
 	"errors"
 	cachemocks "fin-analytics/internal/cache/mocks"
+	client "fin-analytics/internal/grpcclient"
 	grpcmocks "fin-analytics/internal/grpcclient/mocks"
+	repomocks "fin-analytics/internal/repository/mocks"
 	"fin-analytics/internal/service"
+	"fin-analytics/internal/statscalculator"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"fin-analytics/internal/domain"
+	"fin-shared/config"
 )
 
 type ServiceTestSuite struct {
 	suite.Suite
-	mockCache  *cachemocks.StatsCache
-	mockClient *grpcmocks.TransactionClient
-	service    *service.Service
+	mockCache   *cachemocks.StatsCache
+	mockUpdates *cachemocks.UpdatePublisher
+	mockStore   *repomocks.AnalyticsRepository
+	mockClient  *grpcmocks.TransactionClient
+	features    config.Features
+	service     *service.Service
 }
 
 func (s *ServiceTestSuite) SetupTest() {
 	s.mockCache = cachemocks.NewStatsCache(s.T())
+	s.mockUpdates = cachemocks.NewUpdatePublisher(s.T())
+	s.mockStore = repomocks.NewAnalyticsRepository(s.T())
 	s.mockClient = grpcmocks.NewTransactionClient(s.T())
-	s.service = service.New(s.mockCache, s.mockClient)
+	s.features = config.Features{config.FeatureStatsCache: true}
+	s.service = service.New(s.mockCache, s.mockUpdates, s.mockStore, s.mockClient, s.features)
 }
 
-func (s *ServiceTestSuite) TestProcessKafkaMessageSuccess() {
+// sends returns a Run function that passes txs to the callback of a
+// StreamTransactions call as one batch at version 3.
+func sends(txs ...domain.Transaction) func(mock.Arguments) {
+	return sendsAt(3, txs...)
+}
+
+func sendsAt(version int64, txs ...domain.Transaction) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		_ = args.Get(3).(func(domain.TransactionBatch) error)(domain.TransactionBatch{Version: version, Transactions: txs})
+	}
+}
+
+// event marshals a Kafka message for change at version.
+func event(version int64, change domain.TransactionChange) *sarama.ConsumerMessage {
+	payload, _ := json.Marshal(domain.TransactionMessage{UserID: 1, Version: version, Change: &change})
+	return &sarama.ConsumerMessage{Value: payload}
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageAppliesNextChange() {
 	ctx := context.Background()
 	userID := 1
-	txs := []domain.Transaction{
-		{ID: 1, UserID: userID, Amount: 100, Type: domain.TransactionTypeIncome, Category: "Salary"},
-		{ID: 2, UserID: userID, Amount: 50, Type: domain.TransactionTypeExpense, Category: "Food"},
+	march := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	salary := domain.Transaction{ID: 1, UserID: userID, Amount: 100, Type: domain.TransactionTypeIncome, Category: "Salary", CreatedAt: march}
+	food := domain.Transaction{ID: 2, UserID: userID, Amount: 50, Type: domain.TransactionTypeExpense, Category: "Food", CreatedAt: march}
+	bonus := domain.Transaction{ID: 3, UserID: userID, Amount: 30, Type: domain.TransactionTypeIncome, Category: "Bonus", CreatedAt: march}
+	bonusMonth := domain.MonthKey{Month: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), Type: domain.TransactionTypeIncome, Category: "Bonus"}
+	// The new rollup isn't stored yet, so no rollups are loaded.
+	stored := statscalculator.Aggregate(userID, []domain.Transaction{salary, food}, 6)
+	stored.Months = nil
+	s.mockStore.On("LoadMonths", mock.Anything, userID, []domain.MonthKey{bonusMonth}).Return(stored, nil)
+
+	s.mockStore.On("SaveDelta", mock.Anything, domain.AggregatesDelta{
+		UserID:     userID,
+		Income:     domain.Total{Sum: 130, Count: 2, Min: 30, Max: 100},
+		Expense:    domain.Total{Sum: 50, Count: 1, Min: 50, Max: 50},
+		Categories: []domain.CategoryTotal{{Type: domain.TransactionTypeIncome, Category: "Bonus", Sum: 30, Count: 1}},
+		Months: []domain.CategoryMonth{
+			{Month: bonusMonth.Month, Type: domain.TransactionTypeIncome, Category: "Bonus", Total: domain.Total{Sum: 30, Count: 1, Min: 30, Max: 30}},
+		},
+		Version: 7,
+	}).Return(true, nil)
+	s.mockCache.On("Set", mock.Anything, mock.MatchedBy(func(stats domain.FinanceStats) bool {
+		return stats.UserID == userID && stats.TotalIncome == 130 && stats.TotalExpense == 50 && stats.Balance == 80 &&
+			stats.IncomeByCategory["Salary"] == 100 && stats.IncomeByCategory["Bonus"] == 30
+	})).Return(nil)
+	s.mockUpdates.On("Publish", mock.Anything, mock.MatchedBy(func(stats domain.FinanceStats) bool {
+		return stats.UserID == userID && stats.Balance == 80
+	})).Return(nil)
+
+	s.NoError(s.service.ProcessKafkaMessage(ctx, event(7, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: bonus})))
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageNotifiesWatchers() {
+	ctx := context.Background()
+	updates, cancel := s.service.Watch(1)
+	defer cancel()
+	other, cancelOther := s.service.Watch(2)
+	defer cancelOther()
+
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{UserID: 1}, nil).Once()
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{UserID: 1, Income: domain.Total{Sum: 10, Count: 1, Min: 10, Max: 10}, Version: 1}, nil).Once()
+	s.mockStore.On("SaveDelta", mock.Anything, mock.Anything).Return(true, nil)
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+	s.mockUpdates.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	for version, amount := range []float64{10, 20} {
+		tx := domain.Transaction{ID: int64(version + 1), UserID: 1, Amount: amount, Type: domain.TransactionTypeIncome}
+		s.NoError(s.service.ProcessKafkaMessage(ctx, event(int64(version+1), domain.TransactionChange{Op: domain.ChangeCreate, Transaction: tx})))
 	}
-	payload := domain.TransactionMessage{
-		UserID:       userID,
-		Transactions: txs,
+
+	// Only the latest update is kept for a subscriber that has not read yet.
+	stats := <-updates
+	s.Equal(30.0, stats.TotalIncome)
+	s.Empty(updates)
+	s.Empty(other)
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageIgnoresFailedUpdatePublish() {
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{UserID: 1}, nil)
+	s.mockStore.On("SaveDelta", mock.Anything, mock.Anything).Return(true, nil)
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+	s.mockUpdates.On("Publish", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+	tx := domain.Transaction{ID: 1, UserID: 1, Amount: 5, Type: domain.TransactionTypeIncome}
+	s.NoError(s.service.ProcessKafkaMessage(context.Background(), event(1, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: tx})))
+}
+
+func (s *ServiceTestSuite) TestDeliverNotifiesWatchers() {
+	updates, cancel := s.service.Watch(1)
+	defer cancel()
+
+	s.service.Deliver(domain.FinanceStats{UserID: 1, TotalIncome: 30})
+	s.service.Deliver(domain.FinanceStats{UserID: 2, TotalIncome: 40})
+
+	stats := <-updates
+	s.Equal(30.0, stats.TotalIncome)
+	s.Empty(updates)
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageSkipsOutdatedEvent() {
+	ctx := context.Background()
+	updates, cancel := s.service.Watch(1)
+	defer cancel()
+
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{UserID: 1, Version: 3}, nil)
+
+	tx := domain.Transaction{ID: 1, UserID: 1, Amount: 5, Type: domain.TransactionTypeIncome}
+	s.NoError(s.service.ProcessKafkaMessage(ctx, event(3, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: tx})))
+	s.Empty(updates)
+	s.mockStore.AssertNotCalled(s.T(), "SaveDelta", mock.Anything, mock.Anything)
+	s.mockCache.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything)
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageReturnsStoreError() {
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{}, errors.New("connection refused"))
+
+	tx := domain.Transaction{ID: 1, UserID: 1, Amount: 5, Type: domain.TransactionTypeIncome}
+	err := s.service.ProcessKafkaMessage(context.Background(), event(4, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: tx}))
+	s.ErrorContains(err, "connection refused")
+	s.mockClient.AssertNotCalled(s.T(), "StreamTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceTestSuite) TestProcessKafkaMessageRebuildsWhenChangeCantBeApplied() {
+	march := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	salary := domain.Transaction{ID: 1, UserID: 1, Amount: 100, Type: domain.TransactionTypeIncome, Category: "Salary", CreatedAt: march}
+	food := domain.Transaction{ID: 2, UserID: 1, Amount: 50, Type: domain.TransactionTypeExpense, Category: "Food", CreatedAt: march}
+	rent := domain.Transaction{ID: 3, UserID: 1, Amount: 700, Type: domain.TransactionTypeExpense, Category: "Rent", CreatedAt: march}
+	snapshot := []domain.Transaction{salary, food}
+	legacy, _ := json.Marshal(domain.TransactionMessage{UserID: 1})
+
+	tests := map[string]struct {
+		msg        *sarama.ConsumerMessage
+		stored     domain.Aggregates
+		loadErr    error
+		skipsLoad  bool
+		minVersion int64
+	}{
+		"legacy event": {
+			msg:       &sarama.ConsumerMessage{Value: legacy},
+			skipsLoad: true,
+		},
+		"nothing stored": {
+			msg:        event(7, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: food}),
+			loadErr:    domain.ErrAggregatesNotFound,
+			minVersion: 7,
+		},
+		"an event was missed": {
+			msg:        event(7, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: food}),
+			stored:     statscalculator.Aggregate(1, []domain.Transaction{salary}, 5),
+			minVersion: 7,
+		},
+		"deleted transaction is unknown": {
+			msg:        event(7, domain.TransactionChange{Op: domain.ChangeDelete, Transaction: domain.Transaction{ID: 4, Type: domain.TransactionTypeIncome, Category: "Gift", CreatedAt: march}}),
+			stored:     statscalculator.Aggregate(1, snapshot, 6),
+			minVersion: 7,
+		},
+		"max was deleted": {
+			msg:        event(7, domain.TransactionChange{Op: domain.ChangeDelete, Transaction: rent}),
+			stored:     statscalculator.Aggregate(1, []domain.Transaction{salary, food, rent}, 6),
+			minVersion: 7,
+		},
 	}
-	payloadBytes, _ := json.Marshal(payload)
+	for name, tt := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			ctx := context.Background()
+			if !tt.skipsLoad {
+				s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(tt.stored, tt.loadErr)
+			}
+			s.mockClient.On("StreamTransactions", mock.Anything, 1, tt.minVersion, mock.Anything).Run(sendsAt(8, snapshot...)).Return(nil).Once()
 
-	msg := &sarama.ConsumerMessage{
-		Value: payloadBytes,
+			want := statscalculator.Aggregate(1, snapshot, 8)
+			s.mockStore.On("Save", mock.Anything, want).Return(true, nil)
+			s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+			s.mockUpdates.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+			s.NoError(s.service.ProcessKafkaMessage(ctx, tt.msg))
+		})
 	}
+}
 
-	s.mockCache.On("Set", ctx, mock.MatchedBy(func(stats domain.FinanceStats) bool {
-		return stats.UserID == userID && stats.TotalIncome == 100 && stats.TotalExpense == 50 && stats.Balance == 50
-	})).Return(nil)
+func (s *ServiceTestSuite) TestProcessKafkaMessageRedeliversWhenRebuildFails() {
+	s.mockStore.On("LoadMonths", mock.Anything, 1, mock.Anything).Return(domain.Aggregates{UserID: 1, Version: 2}, nil)
+	s.mockClient.On("StreamTransactions", mock.Anything, 1, int64(5), mock.Anything).Return(client.ErrCircuitOpen)
 
-	err := s.service.ProcessKafkaMessage(ctx, msg)
-	s.NoError(err)
+	tx := domain.Transaction{ID: 1, UserID: 1, Amount: 5, Type: domain.TransactionTypeIncome}
+	err := s.service.ProcessKafkaMessage(context.Background(), event(5, domain.TransactionChange{Op: domain.ChangeCreate, Transaction: tx}))
+	s.ErrorIs(err, client.ErrCircuitOpen)
+	s.mockStore.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
 }
 
 func (s *ServiceTestSuite) TestGetStatsCached() {
@@ -77,8 +254,12 @@ func (s *ServiceTestSuite) TestGetStatsNotCachedSuccess() {
 	}
 
 	s.mockCache.On("Get", ctx, userID).Return(nil, errors.New("not found"))
-	s.mockClient.On("FetchTransactions", ctx, userID).Return(txs, nil)
-	s.mockCache.On("Set", ctx, mock.MatchedBy(func(stats domain.FinanceStats) bool {
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound)
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).Run(sends(txs...)).Return(nil)
+	s.mockStore.On("Save", mock.Anything, mock.MatchedBy(func(agg domain.Aggregates) bool {
+		return agg.UserID == userID && agg.Version == 3
+	})).Return(true, nil)
+	s.mockCache.On("Set", mock.Anything, mock.MatchedBy(func(stats domain.FinanceStats) bool {
 		return stats.TotalIncome == 200
 	})).Return(nil)
 
@@ -87,18 +268,260 @@ func (s *ServiceTestSuite) TestGetStatsNotCachedSuccess() {
 	s.Equal(200.0, stats.TotalIncome)
 }
 
+func (s *ServiceTestSuite) TestGetStatsFromStore() {
+	ctx := context.Background()
+	userID := 1
+	agg := domain.Aggregates{UserID: userID, Income: domain.Total{Sum: 400, Count: 2}, Version: 9}
+
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(agg, nil)
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.Equal(400.0, stats.TotalIncome)
+	s.Equal(200.0, stats.AverageIncome)
+	s.mockClient.AssertNotCalled(s.T(), "StreamTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceTestSuite) TestGetStatsReloadsWhenEventWinsOverFetch() {
+	ctx := context.Background()
+	userID := 1
+
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound).Once()
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).
+		Run(sends(domain.Transaction{UserID: userID, Amount: 1, Type: domain.TransactionTypeIncome})).
+		Return(nil)
+	s.mockStore.On("Save", mock.Anything, mock.Anything).Return(false, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{UserID: userID, Income: domain.Total{Sum: 2, Count: 2}}, nil).Once()
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.Equal(2.0, stats.TotalIncome)
+}
+
+func (s *ServiceTestSuite) TestGetStatsRecomputesWhileStoreFails() {
+	ctx := context.Background()
+	userID := 1
+	down := errors.New("connection refused")
+
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, down)
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).
+		Run(sends(domain.Transaction{UserID: userID, Amount: 3, Type: domain.TransactionTypeExpense})).
+		Return(nil)
+	s.mockStore.On("Save", mock.Anything, mock.Anything).Return(false, down)
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.Equal(3.0, stats.TotalExpense)
+}
+
+func (s *ServiceTestSuite) TestGetTimeSeriesByMonthUsesRollups() {
+	ctx := context.Background()
+	userID := 1
+	march := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	txs := []domain.Transaction{
+		{UserID: userID, Amount: 10, Type: domain.TransactionTypeIncome, CreatedAt: march.Add(time.Hour)},
+		{UserID: userID, Amount: 4, Type: domain.TransactionTypeExpense, CreatedAt: march.AddDate(0, 1, 2)},
+	}
+	s.mockStore.On("Load", mock.Anything, userID).Return(statscalculator.Aggregate(userID, txs, 1), nil)
+
+	series, err := s.service.GetTimeSeries(ctx, userID, domain.IntervalMonth, march, march.AddDate(0, 1, 0))
+	s.NoError(err)
+	s.Equal([]domain.TimeSeriesPoint{{PeriodStart: march, Income: 10, Balance: 10, TransactionsCount: 1}}, series)
+	s.mockClient.AssertNotCalled(s.T(), "StreamTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceTestSuite) TestGetStatsSkipsCacheWhenDisabled() {
+	ctx := context.Background()
+	userID := 1
+	txs := []domain.Transaction{
+		{ID: 1, UserID: userID, Amount: 300, Type: domain.TransactionTypeIncome},
+	}
+	s.features[config.FeatureStatsCache] = false
+
+	s.mockStore.On("Load", mock.Anything, userID).Return(statscalculator.Aggregate(userID, txs, 1), nil)
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil)
+
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.Equal(300.0, stats.TotalIncome)
+	s.mockCache.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+}
+
 func (s *ServiceTestSuite) TestGetStatsFetchError() {
 	ctx := context.Background()
 	userID := 1
 
 	s.mockCache.On("Get", ctx, userID).Return(nil, errors.New("not found"))
-	s.mockClient.On("FetchTransactions", ctx, userID).Return(nil, errors.New("fetch error"))
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound)
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).Return(errors.New("fetch error"))
 
 	_, err := s.service.GetStats(ctx, userID)
 	s.Error(err)
 	s.Equal("fetch error", err.Error())
 }
 
+func (s *ServiceTestSuite) TestGetStatsServesStaleWhenFinAPIUnavailable() {
+	ctx := context.Background()
+	userID := 1
+
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound)
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).Return(client.ErrCircuitOpen)
+	s.mockCache.On("GetStale", ctx, userID).Return(&domain.FinanceStats{UserID: userID, TotalIncome: 42}, nil)
+
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.True(stats.Stale)
+	s.Equal(42.0, stats.TotalIncome)
+	s.mockCache.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything)
+}
+
+func (s *ServiceTestSuite) TestGetStatsUnavailableWithoutStaleCopy() {
+	ctx := context.Background()
+	userID := 1
+
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound)
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).Return(client.ErrCircuitOpen)
+	s.mockCache.On("GetStale", ctx, userID).Return(nil, nil)
+
+	_, err := s.service.GetStats(ctx, userID)
+	s.ErrorIs(err, client.ErrCircuitOpen)
+}
+
+func (s *ServiceTestSuite) TestGetStatsSharesOneFetchBetweenConcurrentMisses() {
+	ctx := context.Background()
+	userID := 1
+	const callers = 10
+	release := make(chan struct{})
+
+	var misses sync.WaitGroup
+	misses.Add(callers)
+	s.mockCache.On("Get", ctx, userID).Return(nil, nil).Run(func(mock.Arguments) { misses.Done() })
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound).Once()
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-release
+			sends(domain.Transaction{UserID: userID, Amount: 5, Type: domain.TransactionTypeIncome})(args)
+		}).
+		Return(nil).
+		Once()
+	s.mockStore.On("Save", mock.Anything, mock.Anything).Return(true, nil).Once()
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil).Once()
+
+	var wg sync.WaitGroup
+	results := make(chan domain.FinanceStats, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats, err := s.service.GetStats(ctx, userID)
+			s.NoError(err)
+			results <- stats
+		}()
+	}
+	misses.Wait()
+	// Let every caller reach the in-flight load before it finishes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for stats := range results {
+		s.Equal(5.0, stats.TotalIncome)
+	}
+	s.mockClient.AssertNumberOfCalls(s.T(), "StreamTransactions", 1)
+}
+
+func (s *ServiceTestSuite) TestGetStatsLoadOutlivesTheCallerThatStartedIt() {
+	userID := 1
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s.mockCache.On("Get", mock.Anything, userID).Return(nil, nil)
+	s.mockStore.On("Load", mock.Anything, userID).Return(domain.Aggregates{}, domain.ErrAggregatesNotFound).Once()
+	s.mockClient.On("StreamTransactions", mock.Anything, userID, int64(0), mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+			s.NoError(args.Get(0).(context.Context).Err(), "the shared load was canceled")
+			sends(domain.Transaction{UserID: userID, Amount: 5, Type: domain.TransactionTypeIncome})(args)
+		}).
+		Return(nil).
+		Once()
+	s.mockStore.On("Save", mock.Anything, mock.Anything).Return(true, nil).Once()
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil).Once()
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := s.service.GetStats(first, userID)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan domain.FinanceStats, 1)
+	go func() {
+		stats, err := s.service.GetStats(context.Background(), userID)
+		s.NoError(err)
+		second <- stats
+	}()
+	// Let the second caller join the load before the first one leaves.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	s.ErrorIs(<-firstErr, context.Canceled)
+
+	close(release)
+	s.Equal(5.0, (<-second).TotalIncome)
+	s.mockClient.AssertNumberOfCalls(s.T(), "StreamTransactions", 1)
+}
+
+func (s *ServiceTestSuite) TestGetStatsRefreshesOldStatsInBackground() {
+	ctx := context.Background()
+	userID := 1
+	s.service.SetSoftTTL(time.Minute)
+	old := &domain.FinanceStats{UserID: userID, TotalIncome: 1, GeneratedAt: time.Now().Add(-2 * time.Minute)}
+	refreshed := make(chan domain.FinanceStats, 1)
+
+	s.mockCache.On("Get", ctx, userID).Return(old, nil)
+	s.mockStore.On("Load", mock.Anything, userID).
+		Return(domain.Aggregates{UserID: userID, Income: domain.Total{Sum: 2, Count: 1}}, nil).
+		Once()
+	s.mockCache.On("Set", mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { refreshed <- args.Get(1).(domain.FinanceStats) }).
+		Once()
+
+	// The old stats are served right away; the refresh happens after.
+	stats, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.Equal(1.0, stats.TotalIncome)
+
+	select {
+	case stats := <-refreshed:
+		s.Equal(2.0, stats.TotalIncome)
+	case <-time.After(time.Second):
+		s.Fail("stats were not refreshed")
+	}
+}
+
+func (s *ServiceTestSuite) TestGetStatsDoesNotRefreshFreshStats() {
+	ctx := context.Background()
+	userID := 1
+	s.service.SetSoftTTL(time.Minute)
+
+	s.mockCache.On("Get", ctx, userID).Return(&domain.FinanceStats{UserID: userID, GeneratedAt: time.Now()}, nil)
+
+	_, err := s.service.GetStats(ctx, userID)
+	s.NoError(err)
+	s.mockStore.AssertNotCalled(s.T(), "Load", mock.Anything, mock.Anything)
+}
+
 func TestServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ServiceTestSuite))
 }
@@ -0,0 +1,186 @@
+package statscalculator
+
+import (
+	"sort"
+	"time"
+
+	"fin-analytics/internal/domain"
+)
+
+type categoryKey struct {
+	txType   domain.TransactionType
+	category string
+}
+
+// Aggregate rolls the user's transactions up into the totals and monthly
+// category rollups kept in the analytics store.
+func Aggregate(userID int, transactions []domain.Transaction, version int64) domain.Aggregates {
+	aggregator := NewAggregator(domain.Aggregates{UserID: userID, Version: version})
+	for _, tx := range transactions {
+		aggregator.Add(tx)
+	}
+	return aggregator.Aggregates()
+}
+
+func keyOf(tx domain.Transaction) domain.MonthKey {
+	return domain.MonthKey{Month: periodStart(tx.CreatedAt, domain.IntervalMonth), Type: tx.Type, Category: tx.Category}
+}
+
+// MonthKeys returns the keys of the rollups change touches.
+func MonthKeys(change domain.TransactionChange) []domain.MonthKey {
+	keys := []domain.MonthKey{keyOf(change.Transaction)}
+	if change.Previous != nil && keyOf(*change.Previous) != keys[0] {
+		keys = append(keys, keyOf(*change.Previous))
+	}
+	return keys
+}
+
+// typeTotal returns the total transactions of txType count towards, or nil
+// for an unknown type.
+func typeTotal(agg *domain.Aggregates, txType domain.TransactionType) *domain.Total {
+	switch txType {
+	case domain.TransactionTypeIncome:
+		return &agg.Income
+	case domain.TransactionTypeExpense:
+		return &agg.Expense
+	default:
+		return nil
+	}
+}
+
+func addAmount(total *domain.Total, amount float64) {
+	if total.Count == 0 {
+		total.Min, total.Max = amount, amount
+	} else {
+		total.Min = min(total.Min, amount)
+		total.Max = max(total.Max, amount)
+	}
+	total.Sum += amount
+	total.Count++
+}
+
+// removeAmount takes amount out of total and reports whether the min or
+// max may have gone with it. Only the amounts can tell, and they aren't
+// kept, so Min and Max are then left as they are.
+func removeAmount(total *domain.Total, amount float64) bool {
+	total.Count--
+	if total.Count == 0 {
+		*total = domain.Total{}
+		return false
+	}
+	total.Sum -= amount
+	return amount == total.Min || amount == total.Max
+}
+
+func collectMonths(months map[domain.MonthKey]*domain.CategoryMonth) []domain.CategoryMonth {
+	result := make([]domain.CategoryMonth, 0, len(months))
+	for _, month := range months {
+		result = append(result, *month)
+	}
+	sortMonths(result)
+	return result
+}
+
+func collectCategories(categories map[categoryKey]*domain.CategoryTotal) []domain.CategoryTotal {
+	result := make([]domain.CategoryTotal, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, *category)
+	}
+	sortCategories(result)
+	return result
+}
+
+func sortCategories(categories []domain.CategoryTotal) {
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Category < b.Category
+	})
+}
+
+func sortMonths(months []domain.CategoryMonth) {
+	sort.Slice(months, func(i, j int) bool {
+		a, b := months[i], months[j]
+		if !a.Month.Equal(b.Month) {
+			return a.Month.Before(b.Month)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Category < b.Category
+	})
+}
+
+// Stats derives the user's FinanceStats from the stored aggregates.
+func Stats(agg domain.Aggregates) domain.FinanceStats {
+	stats := domain.FinanceStats{
+		UserID:            agg.UserID,
+		TotalIncome:       agg.Income.Sum,
+		TotalExpense:      agg.Expense.Sum,
+		Balance:           agg.Income.Sum - agg.Expense.Sum,
+		AverageIncome:     mean(agg.Income),
+		AverageExpense:    mean(agg.Expense),
+		ExpenseByCategory: map[string]float64{},
+		IncomeByCategory:  map[string]float64{},
+		TransactionsCount: agg.Income.Count + agg.Expense.Count,
+		GeneratedAt:       time.Now().UTC(),
+	}
+	for _, category := range agg.Categories {
+		switch category.Type {
+		case domain.TransactionTypeIncome:
+			stats.IncomeByCategory[category.Category] += category.Sum
+		case domain.TransactionTypeExpense:
+			stats.ExpenseByCategory[category.Category] += category.Sum
+		}
+	}
+	return stats
+}
+
+func mean(total domain.Total) float64 {
+	if total.Count == 0 {
+		return 0
+	}
+	return total.Sum / float64(total.Count)
+}
+
+// MonthlySeries is CalculateTimeSeries by month computed from the
+// rollups. from and to must be month starts in UTC: a partial month can't
+// be told apart in a rollup.
+func MonthlySeries(agg domain.Aggregates, from, to time.Time) []domain.TimeSeriesPoint {
+	points := map[time.Time]*domain.TimeSeriesPoint{}
+	for _, month := range agg.Months {
+		if month.Month.Before(from) || !month.Month.Before(to) {
+			continue
+		}
+		point, ok := points[month.Month]
+		if !ok {
+			point = &domain.TimeSeriesPoint{PeriodStart: month.Month}
+			points[month.Month] = point
+		}
+		switch month.Type {
+		case domain.TransactionTypeIncome:
+			point.Income += month.Sum
+		case domain.TransactionTypeExpense:
+			point.Expense += month.Sum
+		}
+		point.TransactionsCount += month.Count
+	}
+
+	series := make([]domain.TimeSeriesPoint, 0, len(points))
+	for _, point := range points {
+		point.Balance = point.Income - point.Expense
+		series = append(series, *point)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].PeriodStart.Before(series[j].PeriodStart)
+	})
+	return series
+}
+
+// IsMonthStart reports whether t is the start of a calendar month in UTC,
+// that is whether it can bound a MonthlySeries.
+func IsMonthStart(t time.Time) bool {
+	return t.Equal(periodStart(t, domain.IntervalMonth))
+}
@@ -0,0 +1,69 @@
+package statscalculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fin-analytics/internal/domain"
+)
+
+func aggregateTransactions() []domain.Transaction {
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	return []domain.Transaction{
+		{ID: 1, UserID: 7, Amount: 1000, Type: domain.TransactionTypeIncome, Category: "salary", CreatedAt: at(time.January, 5)},
+		{ID: 2, UserID: 7, Amount: 300, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: at(time.January, 20)},
+		{ID: 3, UserID: 7, Amount: 150.5, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: at(time.January, 31)},
+		{ID: 4, UserID: 7, Amount: 200, Type: domain.TransactionTypeExpense, Category: "transport", CreatedAt: at(time.February, 1)},
+		{ID: 5, UserID: 7, Amount: 1000, Type: domain.TransactionTypeIncome, Category: "salary", CreatedAt: at(time.March, 5)},
+	}
+}
+
+func TestAggregateRollsUpByMonthAndCategory(t *testing.T) {
+	agg := Aggregate(7, aggregateTransactions(), 42)
+
+	assert.Equal(t, 7, agg.UserID)
+	assert.Equal(t, int64(42), agg.Version)
+	assert.Equal(t, domain.Total{Sum: 2000, Count: 2, Min: 1000, Max: 1000}, agg.Income)
+	assert.Equal(t, domain.Total{Sum: 650.5, Count: 3, Min: 150.5, Max: 300}, agg.Expense)
+
+	january := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []domain.CategoryMonth{
+		{Month: january, Type: domain.TransactionTypeExpense, Category: "food", Total: domain.Total{Sum: 450.5, Count: 2, Min: 150.5, Max: 300}},
+		{Month: january, Type: domain.TransactionTypeIncome, Category: "salary", Total: domain.Total{Sum: 1000, Count: 1, Min: 1000, Max: 1000}},
+		{Month: january.AddDate(0, 1, 0), Type: domain.TransactionTypeExpense, Category: "transport", Total: domain.Total{Sum: 200, Count: 1, Min: 200, Max: 200}},
+		{Month: january.AddDate(0, 2, 0), Type: domain.TransactionTypeIncome, Category: "salary", Total: domain.Total{Sum: 1000, Count: 1, Min: 1000, Max: 1000}},
+	}, agg.Months)
+}
+
+func TestStatsMatchCalculateStats(t *testing.T) {
+	txs := aggregateTransactions()
+	want := CalculateStats(txs)
+	got := Stats(Aggregate(7, txs, 1))
+
+	got.GeneratedAt = want.GeneratedAt
+	assert.Equal(t, want, got)
+}
+
+func TestStatsOfUserWithoutTransactions(t *testing.T) {
+	stats := Stats(Aggregate(7, nil, 1))
+
+	assert.Equal(t, 7, stats.UserID, "the user comes from the event, not the transactions")
+	assert.Zero(t, stats.TransactionsCount)
+	assert.NotNil(t, stats.ExpenseByCategory)
+}
+
+func TestMonthlySeriesMatchesCalculateTimeSeries(t *testing.T) {
+	txs := aggregateTransactions()
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t,
+		CalculateTimeSeries(txs, domain.IntervalMonth, from, to),
+		MonthlySeries(Aggregate(7, txs, 1), from, to))
+	assert.True(t, IsMonthStart(from))
+	assert.False(t, IsMonthStart(from.Add(time.Hour)))
+}
@@ -0,0 +1,188 @@
+package statscalculator
+
+import (
+	"errors"
+	"fmt"
+
+	"fin-analytics/internal/domain"
+)
+
+// ErrUnknownTransaction is returned by Aggregator.Apply for a change that
+// removes a transaction the aggregates don't count, which means they
+// missed an earlier change.
+var ErrUnknownTransaction = errors.New("transaction is not in the aggregates")
+
+// Aggregator applies single transaction changes to a user's aggregates.
+// A change touches one type total, one category total and one monthly
+// rollup, two of each for an update, so it costs O(1) however many
+// transactions the user has. It needs only the rollups the change touches,
+// as loaded for MonthKeys; Delta returns what it changed.
+//
+// Sums and counts, and with them the averages, follow every change
+// exactly. A min or max can't be restored when the transaction that held
+// it is removed: the total or rollup is marked stale and keeps its old
+// value, and the aggregates must be rebuilt from the transactions.
+type Aggregator struct {
+	agg         domain.Aggregates
+	categories  map[categoryKey]*domain.CategoryTotal
+	months      map[domain.MonthKey]*domain.CategoryMonth
+	staleTotals map[domain.TransactionType]bool
+	staleMonths map[domain.MonthKey]bool
+	// touched holds the rollups changed by Apply.
+	touched map[domain.MonthKey]bool
+}
+
+func NewAggregator(agg domain.Aggregates) *Aggregator {
+	a := &Aggregator{
+		agg:         agg,
+		categories:  make(map[categoryKey]*domain.CategoryTotal, len(agg.Categories)),
+		months:      make(map[domain.MonthKey]*domain.CategoryMonth, len(agg.Months)),
+		staleTotals: map[domain.TransactionType]bool{},
+		staleMonths: map[domain.MonthKey]bool{},
+		touched:     map[domain.MonthKey]bool{},
+	}
+	for _, category := range agg.Categories {
+		a.categories[categoryKey{category.Type, category.Category}] = &category
+	}
+	for _, month := range agg.Months {
+		a.months[domain.MonthKey{Month: month.Month, Type: month.Type, Category: month.Category}] = &month
+	}
+	a.agg.Categories = nil
+	a.agg.Months = nil
+	return a
+}
+
+// Apply applies change. On error the aggregator is left partly updated
+// and must be dropped.
+func (a *Aggregator) Apply(change domain.TransactionChange) error {
+	for _, key := range MonthKeys(change) {
+		a.touched[key] = true
+	}
+	switch change.Op {
+	case domain.ChangeCreate:
+		a.add(change.Transaction)
+		return nil
+	case domain.ChangeUpdate:
+		if change.Previous == nil {
+			return errors.New("update without the previous transaction")
+		}
+		if err := a.remove(*change.Previous); err != nil {
+			return err
+		}
+		a.add(change.Transaction)
+		return nil
+	case domain.ChangeDelete:
+		return a.remove(change.Transaction)
+	default:
+		return fmt.Errorf("unknown change %q", change.Op)
+	}
+}
+
+// Add counts tx. Aggregates streamed from fin-api are folded in with it
+// one transaction at a time.
+func (a *Aggregator) Add(tx domain.Transaction) {
+	a.add(tx)
+}
+
+func (a *Aggregator) add(tx domain.Transaction) {
+	total := typeTotal(&a.agg, tx.Type)
+	if total == nil {
+		return
+	}
+	addAmount(total, tx.Amount)
+
+	category, ok := a.categories[categoryKey{tx.Type, tx.Category}]
+	if !ok {
+		category = &domain.CategoryTotal{Type: tx.Type, Category: tx.Category}
+		a.categories[categoryKey{tx.Type, tx.Category}] = category
+	}
+	category.Sum += tx.Amount
+	category.Count++
+
+	key := keyOf(tx)
+	month, ok := a.months[key]
+	if !ok {
+		month = &domain.CategoryMonth{Month: key.Month, Type: tx.Type, Category: tx.Category}
+		a.months[key] = month
+	}
+	addAmount(&month.Total, tx.Amount)
+}
+
+func (a *Aggregator) remove(tx domain.Transaction) error {
+	total := typeTotal(&a.agg, tx.Type)
+	if total == nil {
+		return nil
+	}
+	key := keyOf(tx)
+	month, ok := a.months[key]
+	category, found := a.categories[categoryKey{tx.Type, tx.Category}]
+	if !ok || !found || total.Count == 0 {
+		return fmt.Errorf("remove transaction %d: %w", tx.ID, ErrUnknownTransaction)
+	}
+
+	category.Count--
+	category.Sum -= tx.Amount
+	if category.Count == 0 {
+		delete(a.categories, categoryKey{tx.Type, tx.Category})
+	}
+
+	if removeAmount(total, tx.Amount) {
+		a.staleTotals[tx.Type] = true
+	} else if total.Count == 0 {
+		delete(a.staleTotals, tx.Type)
+	}
+
+	if removeAmount(&month.Total, tx.Amount) {
+		a.staleMonths[key] = true
+	} else if month.Count == 0 {
+		delete(a.months, key)
+		delete(a.staleMonths, key)
+	}
+	return nil
+}
+
+// NeedsRecompute reports whether a min or max is stale, so the
+// aggregates must be rebuilt from the transactions instead.
+func (a *Aggregator) NeedsRecompute() bool {
+	return len(a.staleTotals) > 0 || len(a.staleMonths) > 0
+}
+
+// Aggregates returns the aggregates with every applied change. Its Months
+// are those the aggregator was created with, so they are partial when only
+// the touched rollups were loaded.
+func (a *Aggregator) Aggregates() domain.Aggregates {
+	agg := a.agg
+	agg.Categories = collectCategories(a.categories)
+	agg.Months = collectMonths(a.months)
+	return agg
+}
+
+// Delta returns the totals and the category totals and rollups changed by
+// Apply, with a zero Count for those that were emptied.
+func (a *Aggregator) Delta() domain.AggregatesDelta {
+	delta := domain.AggregatesDelta{
+		UserID:  a.agg.UserID,
+		Income:  a.agg.Income,
+		Expense: a.agg.Expense,
+		Version: a.agg.Version,
+	}
+	categories := map[categoryKey]bool{}
+	for key := range a.touched {
+		categories[categoryKey{key.Type, key.Category}] = true
+		month := domain.CategoryMonth{Month: key.Month, Type: key.Type, Category: key.Category}
+		if stored, ok := a.months[key]; ok {
+			month = *stored
+		}
+		delta.Months = append(delta.Months, month)
+	}
+	for key := range categories {
+		category := domain.CategoryTotal{Type: key.txType, Category: key.category}
+		if stored, ok := a.categories[key]; ok {
+			category = *stored
+		}
+		delta.Categories = append(delta.Categories, category)
+	}
+	sortCategories(delta.Categories)
+	sortMonths(delta.Months)
+	return delta
+}
@@ -0,0 +1,170 @@
+package statscalculator
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+
+	"fin-analytics/internal/domain"
+)
+
+var (
+	testMonths = []time.Time{
+		time.Date(2026, time.January, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.February, 14, 12, 0, 0, 0, time.UTC),
+	}
+	testCategories = []string{"food", "rent", "salary"}
+	testTypes      = []domain.TransactionType{domain.TransactionTypeIncome, domain.TransactionTypeExpense}
+	testOps        = []domain.ChangeOp{domain.ChangeCreate, domain.ChangeUpdate, domain.ChangeDelete}
+)
+
+// transactionGen draws transactions that often share a rollup and an
+// amount, so that removals keep hitting the min and max. Amounts are
+// quarters, which float64 adds up exactly in any order, so the results can
+// be compared for equality.
+func transactionGen() *rapid.Generator[domain.Transaction] {
+	return rapid.Custom(func(t *rapid.T) domain.Transaction {
+		return domain.Transaction{
+			UserID:    1,
+			Amount:    float64(rapid.IntRange(1, 20).Draw(t, "quarters")) / 4,
+			Category:  rapid.SampledFrom(testCategories).Draw(t, "category"),
+			Type:      rapid.SampledFrom(testTypes).Draw(t, "type"),
+			CreatedAt: rapid.SampledFrom(testMonths).Draw(t, "created_at"),
+		}
+	})
+}
+
+// TestAggregatorMatchesFullRecalculation applies random sequences of
+// changes and compares the result after every change with the aggregates
+// and stats computed from all of the transactions.
+func TestAggregatorMatchesFullRecalculation(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var live []domain.Transaction
+		var nextID int64
+		aggregator := NewAggregator(domain.Aggregates{UserID: 1})
+
+		steps := rapid.IntRange(1, 60).Draw(t, "steps")
+		for range steps {
+			op := domain.ChangeCreate
+			if len(live) > 0 {
+				op = rapid.SampledFrom(testOps).Draw(t, "op")
+			}
+
+			change := domain.TransactionChange{Op: op}
+			switch op {
+			case domain.ChangeCreate:
+				nextID++
+				change.Transaction = transactionGen().Draw(t, "created")
+				change.Transaction.ID = nextID
+				live = append(live, change.Transaction)
+			case domain.ChangeUpdate:
+				i := rapid.IntRange(0, len(live)-1).Draw(t, "updated")
+				previous := live[i]
+				change.Previous = &previous
+				// fin-api never changes created_at.
+				change.Transaction = transactionGen().Draw(t, "update")
+				change.Transaction.ID, change.Transaction.CreatedAt = previous.ID, previous.CreatedAt
+				live[i] = change.Transaction
+			case domain.ChangeDelete:
+				i := rapid.IntRange(0, len(live)-1).Draw(t, "deleted")
+				change.Transaction = live[i]
+				live = slices.Delete(live, i, i+1)
+			}
+
+			require.NoError(t, aggregator.Apply(change))
+			want := Aggregate(1, live, 0)
+			// The service rebuilds stale aggregates from a snapshot.
+			if aggregator.NeedsRecompute() {
+				aggregator = NewAggregator(want)
+			}
+
+			got := aggregator.Aggregates()
+			require.Equal(t, want, got, "after %s of %d", op, change.Transaction.ID)
+			if len(live) > 0 {
+				stats, full := Stats(got), CalculateStats(live)
+				stats.GeneratedAt = full.GeneratedAt
+				require.Equal(t, full, stats)
+			}
+
+			// The aggregates are saved and loaded between events.
+			if rapid.Bool().Draw(t, "reload") {
+				aggregator = NewAggregator(got)
+			}
+		}
+	})
+}
+
+func TestAggregatorMarksRemovedExtremesStale(t *testing.T) {
+	at := testMonths[2]
+	small := domain.Transaction{ID: 1, Amount: 5, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: at}
+	large := domain.Transaction{ID: 2, Amount: 50, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: at}
+	aggregator := NewAggregator(Aggregate(1, []domain.Transaction{small, large}, 3))
+
+	moved := large
+	moved.Amount = 20
+	require.NoError(t, aggregator.Apply(domain.TransactionChange{Op: domain.ChangeUpdate, Transaction: moved, Previous: &large}))
+	assert.True(t, aggregator.NeedsRecompute(), "the max was updated away")
+	agg := aggregator.Aggregates()
+	assert.Equal(t, domain.Total{Sum: 25, Count: 2, Min: 5, Max: 50}, agg.Expense, "the stale max is kept")
+	assert.Equal(t, int64(3), agg.Version)
+
+	aggregator = NewAggregator(Aggregate(1, []domain.Transaction{small, moved}, 4))
+	require.NoError(t, aggregator.Apply(domain.TransactionChange{Op: domain.ChangeDelete, Transaction: moved}))
+	require.NoError(t, aggregator.Apply(domain.TransactionChange{Op: domain.ChangeDelete, Transaction: small}))
+	assert.False(t, aggregator.NeedsRecompute(), "an empty total has nothing to recompute")
+	assert.Empty(t, aggregator.Aggregates().Months)
+}
+
+func TestAggregatorRejectsChangesItMissed(t *testing.T) {
+	known := domain.Transaction{ID: 1, Amount: 5, Type: domain.TransactionTypeIncome, Category: "salary", CreatedAt: testMonths[0]}
+	unknown := known
+	unknown.Category = "bonus"
+	aggregator := NewAggregator(Aggregate(1, []domain.Transaction{known}, 1))
+
+	err := aggregator.Apply(domain.TransactionChange{Op: domain.ChangeDelete, Transaction: unknown})
+	assert.ErrorIs(t, err, ErrUnknownTransaction)
+
+	err = aggregator.Apply(domain.TransactionChange{Op: domain.ChangeUpdate, Transaction: known})
+	assert.Error(t, err)
+	err = aggregator.Apply(domain.TransactionChange{Op: "merge"})
+	assert.Error(t, err)
+}
+
+func TestAggregatorDeltaHoldsTouchedRollupsOnly(t *testing.T) {
+	rent := domain.Transaction{ID: 1, Amount: 700, Type: domain.TransactionTypeExpense, Category: "rent", CreatedAt: testMonths[0]}
+	food := domain.Transaction{ID: 2, Amount: 50, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: testMonths[0]}
+	coffee := domain.Transaction{ID: 3, Amount: 5, Type: domain.TransactionTypeExpense, Category: "food", CreatedAt: testMonths[2]}
+	full := Aggregate(1, []domain.Transaction{rent, food, coffee}, 3)
+
+	moved := food
+	moved.CreatedAt = testMonths[1]
+	change := domain.TransactionChange{Op: domain.ChangeUpdate, Transaction: moved, Previous: &food}
+	keys := MonthKeys(change)
+	require.Len(t, keys, 2)
+
+	// Only the rollups at keys are loaded, as LoadMonths does.
+	partial := full
+	partial.Months = slices.DeleteFunc(slices.Clone(full.Months), func(month domain.CategoryMonth) bool {
+		return !slices.Contains(keys, domain.MonthKey{Month: month.Month, Type: month.Type, Category: month.Category})
+	})
+	aggregator := NewAggregator(partial)
+	require.NoError(t, aggregator.Apply(change))
+	require.False(t, aggregator.NeedsRecompute())
+
+	assert.Equal(t, domain.AggregatesDelta{
+		UserID:     1,
+		Expense:    domain.Total{Sum: 755, Count: 3, Min: 5, Max: 700},
+		Categories: []domain.CategoryTotal{{Type: domain.TransactionTypeExpense, Category: "food", Sum: 55, Count: 2}},
+		Months: []domain.CategoryMonth{
+			{Month: keys[1].Month, Type: domain.TransactionTypeExpense, Category: "food"},
+			{Month: keys[0].Month, Type: domain.TransactionTypeExpense, Category: "food", Total: domain.Total{Sum: 55, Count: 2, Min: 5, Max: 50}},
+		},
+		Version: 3,
+	}, aggregator.Delta(), "the emptied January rollup has a zero count, rent isn't touched")
+	assert.Equal(t, full.Categories, aggregator.Aggregates().Categories)
+}
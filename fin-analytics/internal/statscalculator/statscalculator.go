@@ -1,6 +1,7 @@
 package statscalculator
 
 import (
+	"sort"
 	"time"
 
 	"fin-analytics/internal/domain"
@@ -51,3 +52,73 @@ func average(values []float64) float64 {
 	}
 	return sum / float64(len(values))
 }
+
+// CalculateTimeSeries buckets the transactions created in [from, to) by
+// interval. Periods start in UTC, weeks on Monday; periods without
+// transactions are omitted and the result is ordered by PeriodStart.
+func CalculateTimeSeries(transactions []domain.Transaction, interval domain.Interval, from, to time.Time) []domain.TimeSeriesPoint {
+	series := NewSeries(interval, from, to)
+	for _, tx := range transactions {
+		series.Add(tx)
+	}
+	return series.Points()
+}
+
+// Series is CalculateTimeSeries built one transaction at a time, for
+// transactions streamed from fin-api.
+type Series struct {
+	interval domain.Interval
+	from, to time.Time
+	points   map[time.Time]*domain.TimeSeriesPoint
+}
+
+func NewSeries(interval domain.Interval, from, to time.Time) *Series {
+	return &Series{interval: interval, from: from, to: to, points: map[time.Time]*domain.TimeSeriesPoint{}}
+}
+
+// Add counts tx if it was created in [from, to).
+func (s *Series) Add(tx domain.Transaction) {
+	if tx.CreatedAt.Before(s.from) || !tx.CreatedAt.Before(s.to) {
+		return
+	}
+	start := periodStart(tx.CreatedAt, s.interval)
+	point, ok := s.points[start]
+	if !ok {
+		point = &domain.TimeSeriesPoint{PeriodStart: start}
+		s.points[start] = point
+	}
+	switch tx.Type {
+	case domain.TransactionTypeIncome:
+		point.Income += tx.Amount
+	case domain.TransactionTypeExpense:
+		point.Expense += tx.Amount
+	}
+	point.TransactionsCount++
+}
+
+// Points returns the series ordered by PeriodStart.
+func (s *Series) Points() []domain.TimeSeriesPoint {
+	series := make([]domain.TimeSeriesPoint, 0, len(s.points))
+	for _, point := range s.points {
+		point.Balance = point.Income - point.Expense
+		series = append(series, *point)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].PeriodStart.Before(series[j].PeriodStart)
+	})
+	return series
+}
+
+func periodStart(t time.Time, interval domain.Interval) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case domain.IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case domain.IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
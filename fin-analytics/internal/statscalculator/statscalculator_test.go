@@ -258,6 +258,43 @@ func (s *CalculatorTestSuite) TestCalculateStatsZeroAmounts() {
 	assert.Equal(s.T(), 0.0, stats.ExpenseByCategory["food"])
 }
 
+func (s *CalculatorTestSuite) TestCalculateTimeSeries() {
+	at := func(value string) time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		s.Require().NoError(err)
+		return t
+	}
+	transactions := []domain.Transaction{
+		{Amount: 100, Type: domain.TransactionTypeIncome, CreatedAt: at("2024-03-04T10:00:00Z")},
+		{Amount: 40, Type: domain.TransactionTypeExpense, CreatedAt: at("2024-03-06T23:30:00Z")},
+		{Amount: 10, Type: domain.TransactionTypeExpense, CreatedAt: at("2024-03-11T01:00:00+03:00")},
+		{Amount: 500, Type: domain.TransactionTypeIncome, CreatedAt: at("2024-04-01T00:00:00Z")},
+		{Amount: 1, Type: domain.TransactionTypeIncome, CreatedAt: at("2024-02-01T00:00:00Z")},
+	}
+	from, to := at("2024-03-01T00:00:00Z"), at("2024-04-01T00:00:00Z")
+
+	days := CalculateTimeSeries(transactions, domain.IntervalDay, from, to)
+	s.Require().Len(days, 3)
+	s.Equal(at("2024-03-04T00:00:00Z"), days[0].PeriodStart)
+	s.Equal(at("2024-03-06T00:00:00Z"), days[1].PeriodStart)
+	s.Equal(at("2024-03-10T00:00:00Z"), days[2].PeriodStart)
+
+	weeks := CalculateTimeSeries(transactions, domain.IntervalWeek, from, to)
+	s.Require().Len(weeks, 1)
+	s.Equal(domain.TimeSeriesPoint{
+		PeriodStart:       at("2024-03-04T00:00:00Z"),
+		Income:            100,
+		Expense:           50,
+		Balance:           50,
+		TransactionsCount: 3,
+	}, weeks[0])
+
+	months := CalculateTimeSeries(transactions, domain.IntervalMonth, from, to.AddDate(0, 1, 0))
+	s.Require().Len(months, 2)
+	s.Equal(at("2024-03-01T00:00:00Z"), months[0].PeriodStart)
+	s.Equal(500.0, months[1].Income)
+}
+
 func TestCalculatorTestSuite(t *testing.T) {
 	suite.Run(t, new(CalculatorTestSuite))
 }
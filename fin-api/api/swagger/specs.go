@@ -2,7 +2,10 @@ package swagger
 
 import _ "embed"
 
-//go:embed fin-api.yaml
+// fin-api.swagger.json is generated by `make proto` from the annotated
+// protos in shared/api; don't edit it by hand.
+//
+//go:embed fin-api.swagger.json
 var finAPISpec []byte
 
 func FinAPISpec() []byte {
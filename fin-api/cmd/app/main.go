@@ -2,43 +2,181 @@ package main
 
 import (
 	"context"
-	"fin-api/config"
-	"fin-api/internal/bootstrap"
+	"crypto/tls"
 	finapigrpc "fin-api/internal/grpc"
 	finapihttp "fin-api/internal/http"
+	"fin-api/internal/idgen"
+	"fin-api/internal/metrics"
+	"fin-api/internal/migrations"
+	"fin-api/internal/ratelimit"
 	"fin-api/internal/repository"
 	"fin-api/internal/service"
-	"log"
+	"fin-shared/auth"
+	"fin-shared/bootstrap"
+	"fin-shared/certs"
+	"fin-shared/config"
+	"fin-shared/health"
+	"fin-shared/logging"
+	"fin-shared/tracing"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
 
 	"fin-api/internal/database"
 	"fin-api/internal/kafka"
 )
 
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	flags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
 
-	cfg, err := config.Load("config.yaml")
+	if flags.PrintConfig {
+		if err := config.Print(os.Stdout, flags.Path, config.FinAPI); err != nil {
+			logging.Fatal("Invalid config", "error", err)
+		}
+		return
+	}
+
+	if err := run(context.Background(), flags.Path); err != nil {
+		logging.Fatal("fin-api stopped with error", "error", err)
+	}
+}
+
+// run wires the components in dependency order. The app stops them in
+// reverse: readiness is failed first, then the servers drain, then the
+// producer and the pools close and the remaining spans are flushed.
+func run(ctx context.Context, configPath string) error {
+	cfg, err := config.Load(configPath, config.FinAPI)
+	if err != nil {
+		return err
+	}
+
+	logger, err := logging.New(cfg.Logging, os.Stdout)
 	if err != nil {
-		log.Fatalf("load config: %v", err)
+		return fmt.Errorf("create logger: %w", err)
+	}
+	slog.SetDefault(logger)
+
+	app := bootstrap.New(cfg.Shutdown.Timeout)
+
+	tracer, err := tracing.New(ctx, cfg.Tracing, "fin-api")
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	app.Append(bootstrap.Hook{Name: "tracing", OnStop: tracer.Shutdown})
+
+	var grpcTLS, httpTLS *tls.Config
+	if cfg.TLS.Enabled {
+		certificates, err := certs.New(cfg.TLS)
+		if err != nil {
+			return app.Abort(fmt.Errorf("load tls certificates: %w", err))
+		}
+		app.Append(bootstrap.Hook{
+			Name: "tls certificates",
+			Run:  certificates.Run,
+			OnStop: func(context.Context) error {
+				certificates.Stop()
+				return nil
+			},
+		})
+		grpcTLS, httpTLS = certificates.ServerConfig(cfg.TLS.ClientAuth), certificates.ServerConfig(false)
 	}
 
 	bucketManager, err := database.NewBucketManager(ctx, cfg.Postgres)
 	if err != nil {
-		log.Fatalf("create shard manager: %v", err)
+		return app.Abort(fmt.Errorf("create shard manager: %w", err))
 	}
-	defer bucketManager.Close()
+	app.Append(bootstrap.Hook{
+		Name: "postgres",
+		OnStop: func(context.Context) error {
+			bucketManager.Close()
+			return nil
+		},
+	})
 
-	producer, err := kafka.NewProducer(cfg.Kafka.Brokers, cfg.App.KafkaTopic)
+	if cfg.Postgres.AutoMigrate {
+		migrator, err := migrations.New(bucketManager)
+		if err != nil {
+			return app.Abort(fmt.Errorf("load migrations: %w", err))
+		}
+		app.Append(bootstrap.Hook{Name: "migrations", OnStart: migrator.Up})
+	}
+
+	m := metrics.New()
+	m.Registry.MustRegister(database.NewPoolCollector(bucketManager.PoolStats))
+
+	producer, err := kafka.NewProducer(cfg.Kafka.Brokers, cfg.App.KafkaTopic, m)
 	if err != nil {
-		log.Fatalf("create kafka producer: %v", err)
+		return app.Abort(fmt.Errorf("create kafka producer: %w", err))
 	}
-	defer producer.Close()
+	app.Append(bootstrap.Hook{
+		Name: "kafka producer",
+		OnStop: func(context.Context) error {
+			return producer.Close()
+		},
+	})
 
-	repo := repository.NewTransactionRepository(bucketManager)
+	ids, err := idgen.New(cfg.FinAPI.NodeID)
+	if err != nil {
+		return app.Abort(fmt.Errorf("create id generator: %w", err))
+	}
+
+	repo := repository.NewTransactionRepository(bucketManager, ids, m)
 	svc := service.NewTransactionService(repo, producer)
-	httpServer := finapihttp.NewServer(svc)
-	grpcServer := finapigrpc.NewServer(svc)
+	adminSvc := service.NewAdminService(repository.NewAdminRepository(bucketManager, cfg.Postgres.ScatterConcurrency))
+
+	ready := health.NewChecker(cfg.Readiness.Timeout)
+	ready.Register("postgres", bucketManager.CheckShards)
+	ready.Register("kafka", health.Simple(producer.Ping))
+
+	limiter := ratelimit.New(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	watcher := config.NewWatcher(configPath, config.FinAPI, cfg)
+	watcher.OnReload(func(cfg *config.Config) {
+		_ = logging.SetLevel(cfg.Logging.Level)
+		limiter.SetLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	})
+	app.Append(bootstrap.Hook{
+		Name: "config watcher",
+		Run:  watcher.Run,
+		OnStop: func(context.Context) error {
+			watcher.Stop()
+			return nil
+		},
+	})
+
+	grpcServer := finapigrpc.NewServer(svc, m, ready, grpcTLS)
+	httpServer, err := finapihttp.NewServer(grpcServer, finapigrpc.NewAdminServer(adminSvc), ready, auth.New(cfg.Auth.APIKeys), limiter, watcher, httpTLS, m)
+	if err != nil {
+		return app.Abort(fmt.Errorf("create http server: %w", err))
+	}
+	httpAddr := fmt.Sprintf("%s:%d", cfg.FinAPI.HTTPHost, cfg.FinAPI.HTTPPort)
+	app.Append(bootstrap.Hook{
+		Name: "http",
+		Run: func() error {
+			slog.Info("fin-api HTTP listening", "addr", httpAddr)
+			return httpServer.Start(httpAddr)
+		},
+		OnStop: httpServer.Stop,
+	})
+
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.FinAPI.GRPCHost, cfg.FinAPI.GRPCPort)
+	app.Append(bootstrap.Hook{
+		Name: "grpc",
+		Run: func() error {
+			slog.Info("fin-api gRPC listening", "addr", grpcAddr)
+			return grpcServer.Start(grpcAddr)
+		},
+		OnStop: grpcServer.Stop,
+	})
+
+	app.Append(bootstrap.Hook{
+		Name: "readiness",
+		OnStop: func(ctx context.Context) error {
+			ready.DrainFor(ctx, cfg.Shutdown.DrainDelay)
+			return nil
+		},
+	})
 
-	bootstrap.RunApp(ctx, cancel, httpServer, grpcServer, cfg)
+	return app.Run(ctx)
 }
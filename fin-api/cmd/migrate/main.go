@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fin-api/internal/database"
+	"fin-api/internal/migrations"
+	"fin-shared/config"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func main() {
+	flags := config.RegisterFlags(flag.CommandLine)
+	statusOnly := flag.Bool("status", false, "report schema versions and drift without applying migrations")
+	flag.Parse()
+
+	if flags.PrintConfig {
+		if err := config.Print(os.Stdout, flags.Path, config.FinAPI); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load(flags.Path, config.FinAPI)
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	bucketManager, err := database.NewBucketManager(ctx, cfg.Postgres)
+	if err != nil {
+		log.Fatalf("create shard manager: %v", err)
+	}
+	defer bucketManager.Close()
+
+	migrator, err := migrations.New(bucketManager)
+	if err != nil {
+		log.Fatalf("load migrations: %v", err)
+	}
+
+	if !*statusOnly {
+		if err := migrator.Up(ctx); err != nil {
+			log.Fatalf("apply migrations: %v", err)
+		}
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatalf("migration status: %v", err)
+	}
+
+	if drift := printStatus(statuses); drift {
+		bucketManager.Close()
+		os.Exit(1)
+	}
+}
+
+func printStatus(statuses []migrations.SchemaStatus) bool {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	drift := false
+	_, _ = fmt.Fprintln(w, "SHARD\tSCHEMA\tVERSION\tPENDING\tDRIFT")
+	for _, status := range statuses {
+		pending := make([]string, 0, len(status.Pending))
+		for _, version := range status.Pending {
+			pending = append(pending, fmt.Sprint(version))
+		}
+		if len(status.Drift) > 0 {
+			drift = true
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			status.Shard,
+			status.Schema,
+			status.Version,
+			orDash(strings.Join(pending, ",")),
+			orDash(strings.Join(status.Drift, "; ")),
+		)
+	}
+	return drift
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
-	"fin-api/config"
+	"fin-api/internal/domain"
+	"fin-api/internal/idgen"
+	"fin-shared/config"
 )
 
 type BucketInfo struct {
@@ -16,12 +20,55 @@ type BucketInfo struct {
 	BucketIndex int
 	ShardName   string
 	Pool        *pgxpool.Pool
+	shard       *shard
+}
+
+func (b *BucketInfo) Schema() string {
+	return fmt.Sprintf("bucket_%d_%d", b.ShardIndex, b.BucketIndex)
+}
+
+// WritePool returns the shard primary unless its circuit breaker is open.
+func (b *BucketInfo) WritePool() (*pgxpool.Pool, error) {
+	if b.shard != nil && !b.shard.breaker.allow() {
+		return nil, fmt.Errorf("%s: %w", b.ShardName, domain.ErrShardUnavailable)
+	}
+	return b.Pool, nil
+}
+
+var defaultPoolConfig = config.PoolConfig{
+	MaxConns:          10,
+	MinConns:          1,
+	MaxConnLifetime:   time.Hour,
+	MaxConnIdleTime:   30 * time.Minute,
+	HealthCheckPeriod: time.Minute,
+	ConnectTimeout:    5 * time.Second,
+}
+
+type bucketKey struct {
+	shardIndex  int
+	bucketIndex int
+}
+
+type shard struct {
+	name     string
+	primary  *pgxpool.Pool
+	replicas []*replica
+	next     atomic.Uint64
+	breaker  *breaker
 }
 
 type BucketManager struct {
-	shards       []*pgxpool.Pool
+	shards       []*shard
 	buckets      []*BucketInfo
+	bucketsByKey map[bucketKey]*BucketInfo
 	totalBuckets int
+	directory    *Directory
+	replicaCfg   config.ReplicaConfig
+	// recentWrites holds the time of each user's last write through this
+	// instance. It is not shared, so read-your-writes holds per instance.
+	recentWrites sync.Map
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
 	mu           sync.RWMutex
 }
 
@@ -29,17 +76,25 @@ func NewBucketManager(ctx context.Context, cfg config.PostgresConfig) (*BucketMa
 	if len(cfg.Shards) == 0 {
 		return nil, fmt.Errorf("no shards configured")
 	}
+	if len(cfg.Shards) > idgen.MaxShards {
+		return nil, fmt.Errorf("too many shards: %d, max %d", len(cfg.Shards), idgen.MaxShards)
+	}
+	for i, shardCfg := range cfg.Shards {
+		if shardCfg.Buckets > idgen.MaxBucketsPerShard {
+			return nil, fmt.Errorf("shard %d: too many buckets: %d, max %d", i, shardCfg.Buckets, idgen.MaxBucketsPerShard)
+		}
+	}
 
-	shardPools := make([]*pgxpool.Pool, len(cfg.Shards))
+	shards := make([]*shard, 0, len(cfg.Shards))
 	for i, shardCfg := range cfg.Shards {
-		pool, err := newShardPool(ctx, shardCfg.ConnURL)
+		s, err := newShard(ctx, shardCfg, shardCfg.Pool.WithDefaults(cfg.Pool), cfg.Health.FailureThreshold)
 		if err != nil {
-			for j := 0; j < i; j++ {
-				shardPools[j].Close()
+			for _, created := range shards {
+				created.close()
 			}
 			return nil, fmt.Errorf("create shard %d: %w", i, err)
 		}
-		shardPools[i] = pool
+		shards = append(shards, s)
 	}
 
 	var buckets []*BucketInfo
@@ -56,29 +111,94 @@ func NewBucketManager(ctx context.Context, cfg config.PostgresConfig) (*BucketMa
 				ShardIndex:  shardIndex,
 				BucketIndex: bucketIdx,
 				ShardName:   shardCfg.Name,
-				Pool:        shardPools[shardIndex],
+				Pool:        shards[shardIndex].primary,
+				shard:       shards[shardIndex],
 			})
 			bucketIndex++
 		}
 	}
 
-	return &BucketManager{
-		shards:       shardPools,
+	bucketsByKey := make(map[bucketKey]*BucketInfo, len(buckets))
+	for _, bucket := range buckets {
+		bucketsByKey[bucketKey{bucket.ShardIndex, bucket.BucketIndex}] = bucket
+	}
+
+	bm := &BucketManager{
+		shards:       shards,
 		buckets:      buckets,
+		bucketsByKey: bucketsByKey,
 		totalBuckets: len(buckets),
-	}, nil
+		replicaCfg:   cfg.Replicas,
+	}
+
+	if cfg.Directory.Enabled {
+		directory, err := NewDirectory(ctx, cfg.Directory, cfg.Pool, bm.hasBucket, func(ctx context.Context, prev, next *routingTable) error {
+			return bm.checkRoutes(ctx, prev, next, bm.usersWithTransactions)
+		})
+		if err != nil {
+			bm.Close()
+			return nil, fmt.Errorf("create directory: %w", err)
+		}
+		bm.directory = directory
+	}
+
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	bm.cancel = cancel
+	bm.wg.Add(2)
+	go func() {
+		defer bm.wg.Done()
+		bm.monitorReplicas(monitorCtx)
+	}()
+	go func() {
+		defer bm.wg.Done()
+		bm.checkHealth(monitorCtx, cfg.Health)
+	}()
+
+	return bm, nil
 }
 
-func newShardPool(ctx context.Context, connURL string) (*pgxpool.Pool, error) {
+func newShard(ctx context.Context, cfg config.PostgresShardConfig, poolCfg config.PoolConfig, failureThreshold int) (*shard, error) {
+	primary, err := newShardPool(ctx, cfg.ConnURL, poolCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &shard{name: cfg.Name, primary: primary, breaker: newBreaker(failureThreshold)}
+	for i, url := range cfg.ReplicaURLs {
+		pool, err := newShardPool(ctx, url, poolCfg)
+		if err != nil {
+			s.close()
+			return nil, fmt.Errorf("create replica %d: %w", i, err)
+		}
+		s.replicas = append(s.replicas, newReplica(pool))
+	}
+
+	return s, nil
+}
+
+func (s *shard) close() {
+	for _, r := range s.replicas {
+		r.pool.Close()
+	}
+	s.primary.Close()
+}
+
+func newShardPool(ctx context.Context, connURL string, poolCfg config.PoolConfig) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(connURL)
 	if err != nil {
 		return nil, fmt.Errorf("parse postgres config: %w", err)
 	}
 
-	config.MaxConns = 10
-	config.MinConns = 1
-	config.MaxConnLifetime = time.Hour
-	config.MaxConnIdleTime = 30 * time.Minute
+	poolCfg = poolCfg.WithDefaults(defaultPoolConfig)
+	config.MaxConns = poolCfg.MaxConns
+	config.MinConns = poolCfg.MinConns
+	config.MaxConnLifetime = poolCfg.MaxConnLifetime
+	config.MaxConnIdleTime = poolCfg.MaxConnIdleTime
+	config.HealthCheckPeriod = poolCfg.HealthCheckPeriod
+	// A connect_timeout in the URL wins over the pool setting.
+	if config.ConnConfig.ConnectTimeout == 0 {
+		config.ConnConfig.ConnectTimeout = poolCfg.ConnectTimeout
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -88,11 +208,83 @@ func newShardPool(ctx context.Context, connURL string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// GetBucketForUser returns the bucket pinned to the user in the directory,
+// falling back to hash routing for unmapped users.
 func (bm *BucketManager) GetBucketForUser(userID int) *BucketInfo {
+	if bm.directory == nil {
+		return bm.routeBucket(nil, userID)
+	}
+	return bm.routeBucket(bm.directory.Lookup, userID)
+}
+
+// GetPoolForUser returns the primary pool of the user's bucket, routed like
+// GetBucketForUser. It bypasses the circuit breaker and the replicas; use
+// GetWritePoolForUser or GetReadPoolForUser to query.
+func (bm *BucketManager) GetPoolForUser(userID int) *pgxpool.Pool {
+	return bm.GetBucketForUser(userID).Pool
+}
+
+// routeBucket returns the bucket lookup routes the user to, or the hash
+// bucket when lookup is nil or has no route to a configured bucket.
+func (bm *BucketManager) routeBucket(lookup func(userID int) (Route, bool), userID int) *BucketInfo {
+	if lookup != nil {
+		if route, ok := lookup(userID); ok {
+			if bucket, ok := bm.bucketsByKey[bucketKey{route.ShardIndex, route.BucketIndex}]; ok {
+				return bucket
+			}
+		}
+	}
+
 	bucketIndex := bm.GetBucketIndex(userID)
 	return bm.buckets[bucketIndex]
 }
 
+// checkRoutes refuses a directory change that would route a user away from
+// the bucket holding their transactions: rows are never moved, so the user
+// would lose them and GlobalStats would count them twice. Every bucket is
+// asked, through usersIn, for the users with transactions in each added or
+// removed route, which must all stay where they are under next.
+func (bm *BucketManager) checkRoutes(ctx context.Context, prev, next *routingTable, usersIn func(ctx context.Context, bucket *BucketInfo, from, to int) ([]int, error)) error {
+	for _, route := range changedRoutes(prev, next) {
+		for _, bucket := range bm.buckets {
+			users, err := usersIn(ctx, bucket, route.UserFrom, route.UserTo)
+			if err != nil {
+				return fmt.Errorf("route %d-%d: %s: %w", route.UserFrom, route.UserTo, bucket.Schema(), err)
+			}
+			for _, userID := range users {
+				if target := bm.routeBucket(next.lookup, userID); target != bucket {
+					return fmt.Errorf("route %d-%d: user %d has transactions in %s and would be routed to %s",
+						route.UserFrom, route.UserTo, userID, bucket.Schema(), target.Schema())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// usersWithTransactions returns the users from..to with transactions in
+// bucket, read on the primary.
+func (bm *BucketManager) usersWithTransactions(ctx context.Context, bucket *BucketInfo, from, to int) ([]int, error) {
+	pool, err := bucket.WritePool()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := pool.Query(ctx, fmt.Sprintf(`
+		SELECT DISTINCT user_id
+		FROM %s.transactions
+		WHERE user_id BETWEEN $1 AND $2
+	`, bucket.Schema()), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int])
+}
+
+func (bm *BucketManager) hasBucket(shardIndex, bucketIndex int) bool {
+	_, ok := bm.bucketsByKey[bucketKey{shardIndex, bucketIndex}]
+	return ok
+}
+
 func (bm *BucketManager) GetBucketIndex(userID int) int {
 	id := userID
 	if id < 0 {
@@ -101,23 +293,66 @@ func (bm *BucketManager) GetBucketIndex(userID int) int {
 	return id % bm.totalBuckets
 }
 
-func (bm *BucketManager) GetPoolForUser(userID int) *pgxpool.Pool {
+// GetWritePoolForUser returns the primary pool of the user's shard, or
+// domain.ErrShardUnavailable while the shard's circuit breaker is open.
+// Callers should report successful mutations with MarkWrite.
+func (bm *BucketManager) GetWritePoolForUser(userID int) (*pgxpool.Pool, error) {
+	return bm.GetBucketForUser(userID).WritePool()
+}
+
+// GetReadPoolForUser returns a replica pool of the user's shard whose lag is
+// within replicas.max_lag. It returns the primary when no replica qualifies
+// or when the user wrote through this instance within
+// replicas.read_your_writes_window.
+// A write through another instance doesn't count: a read routed elsewhere
+// right after a write may miss it until the replica catches up.
+func (bm *BucketManager) GetReadPoolForUser(userID int) (*pgxpool.Pool, error) {
 	bucket := bm.GetBucketForUser(userID)
-	return bucket.Pool
+	if len(bucket.shard.replicas) == 0 || bm.wroteRecently(userID) {
+		return bucket.WritePool()
+	}
+
+	return bm.readPool(bucket)
 }
 
 func (bm *BucketManager) GetBucketSchema(userID int) string {
-	bucket := bm.GetBucketForUser(userID)
-	return fmt.Sprintf("bucket_%d_%d", bucket.ShardIndex, bucket.BucketIndex)
+	return bm.GetBucketForUser(userID).Schema()
+}
+
+// GetBucketForTransaction returns the bucket a transaction was created in,
+// decoded from its ID. ok is false for legacy IDs, which are only unique
+// within their bucket, and for buckets that are no longer configured.
+func (bm *BucketManager) GetBucketForTransaction(transactionID int64) (*BucketInfo, bool) {
+	parts, ok := idgen.Decode(transactionID)
+	if !ok {
+		return nil, false
+	}
+	bucket, ok := bm.bucketsByKey[bucketKey{parts.ShardIndex, parts.BucketIndex}]
+	return bucket, ok
+}
+
+// Buckets returns every configured bucket ordered by shard and bucket index.
+func (bm *BucketManager) Buckets() []*BucketInfo {
+	return bm.buckets
 }
 
 func (bm *BucketManager) Close() {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
 
-	for _, pool := range bm.shards {
-		if pool != nil {
-			pool.Close()
-		}
+	if bm.cancel != nil {
+		bm.cancel()
+		bm.wg.Wait()
+		bm.cancel = nil
+	}
+
+	if bm.directory != nil {
+		bm.directory.Close()
+		bm.directory = nil
+	}
+
+	for _, s := range bm.shards {
+		s.close()
 	}
+	bm.shards = nil
 }
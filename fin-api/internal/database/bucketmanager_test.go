@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-api/internal/idgen"
+)
+
+func TestGetBucketForTransactionRoundTrips(t *testing.T) {
+	s := &shard{name: "shard1", breaker: newBreaker(1)}
+	bm := &BucketManager{bucketsByKey: map[bucketKey]*BucketInfo{}}
+	for i := range 3 {
+		bucket := &BucketInfo{ShardIndex: 1, BucketIndex: i, ShardName: s.name, shard: s}
+		bm.buckets = append(bm.buckets, bucket)
+		bm.bucketsByKey[bucketKey{1, i}] = bucket
+	}
+	ids, err := idgen.New(2)
+	require.NoError(t, err)
+
+	for _, bucket := range bm.buckets {
+		id, err := ids.Next(bucket.ShardIndex, bucket.BucketIndex)
+		require.NoError(t, err)
+		found, ok := bm.GetBucketForTransaction(id)
+		require.True(t, ok)
+		assert.Same(t, bucket, found)
+	}
+
+	removed, err := ids.Next(0, 0)
+	require.NoError(t, err)
+	_, ok := bm.GetBucketForTransaction(removed)
+	assert.False(t, ok, "bucket is not configured")
+	_, ok = bm.GetBucketForTransaction(42)
+	assert.False(t, ok, "legacy id")
+}
+
+func TestCheckRoutesRefusesMovingUsersWithTransactions(t *testing.T) {
+	bm := &BucketManager{bucketsByKey: map[bucketKey]*BucketInfo{}, totalBuckets: 2}
+	for i := range 2 {
+		bucket := &BucketInfo{BucketIndex: i}
+		bm.buckets = append(bm.buckets, bucket)
+		bm.bucketsByKey[bucketKey{0, i}] = bucket
+	}
+	// User 3 hashes to bucket 1, user 7 was pinned to bucket 0; both have
+	// transactions there.
+	rows := map[*BucketInfo][]int{bm.buckets[0]: {7}, bm.buckets[1]: {3}}
+	usersIn := func(_ context.Context, bucket *BucketInfo, from, to int) ([]int, error) {
+		var users []int
+		for _, userID := range rows[bucket] {
+			if userID >= from && userID <= to {
+				users = append(users, userID)
+			}
+		}
+		return users, nil
+	}
+	table := func(routes ...Route) *routingTable {
+		table, err := newRoutingTable(routes)
+		require.NoError(t, err)
+		return table
+	}
+	pinned := Route{UserFrom: 7, UserTo: 7}
+
+	ctx := context.Background()
+	assert.NoError(t, bm.checkRoutes(ctx, table(pinned), table(pinned, Route{UserFrom: 4, UserTo: 5, BucketIndex: 1}), usersIn),
+		"users without transactions can be pinned")
+	assert.ErrorContains(t, bm.checkRoutes(ctx, table(pinned), table(pinned, Route{UserFrom: 3, UserTo: 3}), usersIn),
+		"user 3 has transactions in bucket_0_1 and would be routed to bucket_0_0")
+	assert.ErrorContains(t, bm.checkRoutes(ctx, table(pinned), table(Route{UserFrom: 0, UserTo: 9, BucketIndex: 1}), usersIn),
+		"user 7 has transactions in bucket_0_0")
+	assert.NoError(t, bm.checkRoutes(ctx, table(pinned), table(pinned, Route{UserFrom: 0, UserTo: 9, BucketIndex: 1}), usersIn),
+		"the pin of user 7 wins over the range")
+}
@@ -0,0 +1,297 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"fin-shared/config"
+)
+
+const directoryChannel = "bucket_routes_changed"
+
+const directorySchema = `
+	CREATE TABLE IF NOT EXISTS bucket_routes (
+		user_id_from BIGINT NOT NULL,
+		user_id_to BIGINT NOT NULL,
+		shard_index INTEGER NOT NULL,
+		bucket_index INTEGER NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id_from, user_id_to),
+		CHECK (user_id_from <= user_id_to)
+	);
+
+	CREATE OR REPLACE FUNCTION notify_bucket_routes_changed() RETURNS trigger AS $$
+	BEGIN
+		PERFORM pg_notify('bucket_routes_changed', '');
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql;
+
+	CREATE OR REPLACE TRIGGER bucket_routes_changed
+	AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON bucket_routes
+	FOR EACH STATEMENT EXECUTE FUNCTION notify_bucket_routes_changed();
+`
+
+// Route pins a single user (UserFrom == UserTo) or an inclusive user range
+// to a bucket.
+type Route struct {
+	UserFrom    int
+	UserTo      int
+	ShardIndex  int
+	BucketIndex int
+}
+
+type routingTable struct {
+	pins   map[int]Route
+	ranges []Route
+}
+
+// newRoutingTable indexes routes for lookup. Single-user pins take
+// precedence over ranges; ranges must not overlap each other.
+func newRoutingTable(routes []Route) (*routingTable, error) {
+	table := &routingTable{pins: make(map[int]Route)}
+
+	for _, route := range routes {
+		if route.UserFrom > route.UserTo {
+			return nil, fmt.Errorf("route %d-%d: invalid range", route.UserFrom, route.UserTo)
+		}
+		if route.UserFrom == route.UserTo {
+			table.pins[route.UserFrom] = route
+			continue
+		}
+		table.ranges = append(table.ranges, route)
+	}
+
+	sort.Slice(table.ranges, func(i, j int) bool {
+		return table.ranges[i].UserFrom < table.ranges[j].UserFrom
+	})
+	for i := 1; i < len(table.ranges); i++ {
+		prev, cur := table.ranges[i-1], table.ranges[i]
+		if cur.UserFrom <= prev.UserTo {
+			return nil, fmt.Errorf("route %d-%d overlaps %d-%d", cur.UserFrom, cur.UserTo, prev.UserFrom, prev.UserTo)
+		}
+	}
+
+	return table, nil
+}
+
+// routes returns every route of the table.
+func (t *routingTable) routes() map[Route]bool {
+	routes := make(map[Route]bool, len(t.pins)+len(t.ranges))
+	for _, route := range t.pins {
+		routes[route] = true
+	}
+	for _, route := range t.ranges {
+		routes[route] = true
+	}
+	return routes
+}
+
+// changedRoutes returns the routes added or removed between prev and next.
+func changedRoutes(prev, next *routingTable) []Route {
+	before, after := prev.routes(), next.routes()
+	var changed []Route
+	for route := range after {
+		if !before[route] {
+			changed = append(changed, route)
+		}
+	}
+	for route := range before {
+		if !after[route] {
+			changed = append(changed, route)
+		}
+	}
+	return changed
+}
+
+func (t *routingTable) lookup(userID int) (Route, bool) {
+	if route, ok := t.pins[userID]; ok {
+		return route, true
+	}
+
+	i := sort.Search(len(t.ranges), func(i int) bool {
+		return t.ranges[i].UserFrom > userID
+	})
+	if i == 0 {
+		return Route{}, false
+	}
+	if route := t.ranges[i-1]; userID <= route.UserTo {
+		return route, true
+	}
+	return Route{}, false
+}
+
+// Directory keeps an in-process copy of the bucket_routes table from the
+// control database. The copy is reloaded when the table changes (via
+// LISTEN/NOTIFY) and at least once per refresh interval.
+type Directory struct {
+	pool     *pgxpool.Pool
+	table    atomic.Pointer[routingTable]
+	interval time.Duration
+	known    func(shardIndex, bucketIndex int) bool
+	check    func(ctx context.Context, prev, next *routingTable) error
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewDirectory loads the routes and watches them for changes. Routes to
+// buckets for which known is false are skipped; a change that check
+// rejects is not applied. The routes loaded at startup are not checked.
+func NewDirectory(ctx context.Context, cfg config.DirectoryConfig, poolCfg config.PoolConfig, known func(shardIndex, bucketIndex int) bool, check func(ctx context.Context, prev, next *routingTable) error) (*Directory, error) {
+	if cfg.ConnURL == "" {
+		return nil, fmt.Errorf("directory conn_url is empty")
+	}
+
+	pool, err := newShardPool(ctx, cfg.ConnURL, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create directory pool: %w", err)
+	}
+
+	if _, err := pool.Exec(ctx, directorySchema); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("create directory schema: %w", err)
+	}
+
+	interval := cfg.RefreshInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	d := &Directory{
+		pool:     pool,
+		interval: interval,
+		known:    known,
+		check:    check,
+		done:     make(chan struct{}),
+	}
+	if err := d.Reload(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	go d.watch(watchCtx)
+
+	return d, nil
+}
+
+func (d *Directory) Lookup(userID int) (Route, bool) {
+	table := d.table.Load()
+	if table == nil {
+		return Route{}, false
+	}
+	return table.lookup(userID)
+}
+
+// Reload replaces the cached routing table with the current contents of
+// bucket_routes. Routes that point at buckets unknown to this instance are
+// dropped so that their users fall back to hash routing. A change the check
+// rejects, such as one that moves a user with transactions, is not applied
+// and the current routes stay in effect.
+func (d *Directory) Reload(ctx context.Context) error {
+	rows, err := d.pool.Query(ctx, `
+		SELECT user_id_from, user_id_to, shard_index, bucket_index
+		FROM bucket_routes
+	`)
+	if err != nil {
+		return fmt.Errorf("query bucket routes: %w", err)
+	}
+	defer rows.Close()
+
+	var routes []Route
+	for rows.Next() {
+		var route Route
+		if err := rows.Scan(&route.UserFrom, &route.UserTo, &route.ShardIndex, &route.BucketIndex); err != nil {
+			return fmt.Errorf("scan bucket route: %w", err)
+		}
+		if d.known != nil && !d.known(route.ShardIndex, route.BucketIndex) {
+			slog.Warn("Skipping route to unknown bucket",
+				"user_from", route.UserFrom,
+				"user_to", route.UserTo,
+				"bucket", fmt.Sprintf("bucket_%d_%d", route.ShardIndex, route.BucketIndex),
+			)
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	table, err := newRoutingTable(routes)
+	if err != nil {
+		return fmt.Errorf("build routing table: %w", err)
+	}
+	if prev := d.table.Load(); prev != nil && d.check != nil {
+		if err := d.check(ctx, prev, table); err != nil {
+			return fmt.Errorf("reject bucket routes: %w", err)
+		}
+	}
+
+	d.table.Store(table)
+	return nil
+}
+
+func (d *Directory) watch(ctx context.Context) {
+	defer close(d.done)
+
+	for ctx.Err() == nil {
+		if err := d.listen(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Directory listener failed", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(d.interval):
+			}
+		}
+	}
+}
+
+func (d *Directory) listen(ctx context.Context) error {
+	conn, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+directoryChannel); err != nil {
+		return fmt.Errorf("listen %s: %w", directoryChannel, err)
+	}
+
+	// Routes may have changed while the listener was down.
+	if err := d.Reload(ctx); err != nil {
+		return err
+	}
+
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, d.interval)
+		_, err := conn.Conn().WaitForNotification(waitCtx)
+		cancel()
+
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			// The connection state is unknown after a cancelled wait.
+			conn.Conn().Close(context.Background())
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		if err := d.Reload(ctx); err != nil {
+			slog.Error("Failed to reload bucket routes", "error", err)
+		}
+	}
+}
+
+func (d *Directory) Close() {
+	if d.cancel != nil {
+		d.cancel()
+		<-d.done
+	}
+	d.pool.Close()
+}
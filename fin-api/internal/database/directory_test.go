@@ -0,0 +1,47 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingTableLookup(t *testing.T) {
+	table, err := newRoutingTable([]Route{
+		{UserFrom: 100, UserTo: 199, ShardIndex: 1, BucketIndex: 0},
+		{UserFrom: 1, UserTo: 50, ShardIndex: 0, BucketIndex: 1},
+		{UserFrom: 150, UserTo: 150, ShardIndex: 1, BucketIndex: 1},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		userID int
+		want   Route
+		found  bool
+	}{
+		{name: "range start", userID: 1, want: Route{UserFrom: 1, UserTo: 50, ShardIndex: 0, BucketIndex: 1}, found: true},
+		{name: "range end", userID: 199, want: Route{UserFrom: 100, UserTo: 199, ShardIndex: 1, BucketIndex: 0}, found: true},
+		{name: "pin wins over range", userID: 150, want: Route{UserFrom: 150, UserTo: 150, ShardIndex: 1, BucketIndex: 1}, found: true},
+		{name: "between ranges", userID: 75, found: false},
+		{name: "below all ranges", userID: 0, found: false},
+		{name: "above all ranges", userID: 200, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, ok := table.lookup(tt.userID)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.want, route)
+		})
+	}
+}
+
+func TestRoutingTableRejectsOverlappingRanges(t *testing.T) {
+	_, err := newRoutingTable([]Route{
+		{UserFrom: 1, UserTo: 100},
+		{UserFrom: 50, UserTo: 150},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"fin-shared/config"
+	"fin-shared/health"
+)
+
+const (
+	defaultHealthInterval   = 2 * time.Second
+	defaultHealthTimeout    = time.Second
+	defaultFailureThreshold = 3
+)
+
+type ShardState string
+
+const (
+	ShardHealthy     ShardState = "healthy"
+	ShardUnavailable ShardState = "unavailable"
+)
+
+type ReplicaStatus struct {
+	LagSeconds float64 `json:"lag_seconds"`
+	Usable     bool    `json:"usable"`
+}
+
+type ShardStatus struct {
+	Name      string          `json:"name"`
+	State     ShardState      `json:"state"`
+	Failures  int             `json:"consecutive_failures"`
+	LastError string          `json:"last_error,omitempty"`
+	LastCheck time.Time       `json:"last_check"`
+	Replicas  []ReplicaStatus `json:"replicas,omitempty"`
+}
+
+// breaker trips after threshold consecutive failed health checks and
+// resets on the first successful one. While it is open, requests to the
+// shard primary fail fast with domain.ErrShardUnavailable instead of
+// waiting on a dead connection.
+type breaker struct {
+	mu        sync.RWMutex
+	threshold int
+	failures  int
+	open      bool
+	lastErr   error
+	lastCheck time.Time
+}
+
+func newBreaker(threshold int) *breaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	return &breaker{threshold: threshold}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !b.open
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.open = false
+	b.lastErr = nil
+	b.lastCheck = time.Now()
+}
+
+func (b *breaker) failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastErr = err
+	b.lastCheck = time.Now()
+	if b.failures >= b.threshold {
+		b.open = true
+	}
+}
+
+func (s *shard) status(maxLag time.Duration) ShardStatus {
+	s.breaker.mu.RLock()
+	status := ShardStatus{
+		Name:      s.name,
+		State:     ShardHealthy,
+		Failures:  s.breaker.failures,
+		LastCheck: s.breaker.lastCheck,
+	}
+	if s.breaker.open {
+		status.State = ShardUnavailable
+	}
+	if s.breaker.lastErr != nil {
+		status.LastError = s.breaker.lastErr.Error()
+	}
+	s.breaker.mu.RUnlock()
+
+	for _, r := range s.replicas {
+		status.Replicas = append(status.Replicas, ReplicaStatus{
+			LagSeconds: time.Duration(r.lag.Load()).Seconds(),
+			Usable:     r.usable(maxLag),
+		})
+	}
+	return status
+}
+
+// ShardStatuses returns the health of every shard in configuration order.
+func (bm *BucketManager) ShardStatuses() []ShardStatus {
+	result := make([]ShardStatus, 0, len(bm.shards))
+	for _, s := range bm.shards {
+		result = append(result, s.status(bm.maxLag()))
+	}
+	return result
+}
+
+// CheckShards pings every shard primary for readiness and reports the
+// per-shard result. The instance stays ready while at least one shard
+// answers, since an unavailable shard affects every instance equally and
+// only its own users.
+func (bm *BucketManager) CheckShards(ctx context.Context) (any, error) {
+	statuses := bm.ShardStatuses()
+	errs := make([]error, len(bm.shards))
+
+	var wg sync.WaitGroup
+	for i, s := range bm.shards {
+		wg.Add(1)
+		go func(i int, s *shard) {
+			defer wg.Done()
+			errs[i] = s.primary.Ping(ctx)
+		}(i, s)
+	}
+	wg.Wait()
+
+	available := 0
+	for i, err := range errs {
+		if err != nil {
+			statuses[i].State = ShardUnavailable
+			statuses[i].LastError = err.Error()
+			continue
+		}
+		statuses[i].State = ShardHealthy
+		available++
+	}
+
+	switch {
+	case available == 0:
+		return statuses, fmt.Errorf("no shard available")
+	case available < len(statuses):
+		return statuses, health.Degraded(fmt.Errorf("%d of %d shards unavailable", len(statuses)-available, len(statuses)))
+	}
+	return statuses, nil
+}
+
+func (bm *BucketManager) checkHealth(ctx context.Context, cfg config.HealthCheckConfig) {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultHealthInterval
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var wg sync.WaitGroup
+		for _, s := range bm.shards {
+			wg.Add(1)
+			go func(s *shard) {
+				defer wg.Done()
+
+				pingCtx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+
+				if err := s.primary.Ping(pingCtx); err != nil {
+					if ctx.Err() == nil {
+						s.breaker.failure(err)
+					}
+					return
+				}
+				s.breaker.success()
+			}(s)
+		}
+		wg.Wait()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
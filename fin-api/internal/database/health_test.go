@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-shared/config"
+	"fin-shared/health"
+)
+
+func TestCheckShardsFailsWhenNoShardAnswers(t *testing.T) {
+	pool, err := newShardPool(context.Background(), "postgres://u:p@127.0.0.1:1/db", config.PoolConfig{})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	bm := &BucketManager{shards: []*shard{{name: "shard0", primary: pool, breaker: newBreaker(1)}}}
+
+	details, err := bm.CheckShards(context.Background())
+	require.Error(t, err)
+
+	statuses := details.([]ShardStatus)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, ShardUnavailable, statuses[0].State)
+	assert.NotEmpty(t, statuses[0].LastError)
+
+	checker := health.NewChecker(0)
+	checker.Register("postgres", bm.CheckShards)
+	assert.Equal(t, health.StatusFail, checker.Run(context.Background()).Status)
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PoolStat struct {
+	Shard string
+	// Role is "primary" or "replicaN".
+	Role string
+	Stat *pgxpool.Stat
+}
+
+// PoolStats snapshots every primary and replica pool.
+func (bm *BucketManager) PoolStats() []PoolStat {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	var stats []PoolStat
+	for _, s := range bm.shards {
+		stats = append(stats, PoolStat{Shard: s.name, Role: "primary", Stat: s.primary.Stat()})
+		for i, r := range s.replicas {
+			stats = append(stats, PoolStat{Shard: s.name, Role: fmt.Sprintf("replica%d", i), Stat: r.pool.Stat()})
+		}
+	}
+	return stats
+}
+
+type poolCollector struct {
+	stats func() []PoolStat
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+}
+
+// NewPoolCollector exports pgxpool statistics labelled by shard and role.
+// Values are read from the pools on every scrape.
+func NewPoolCollector(stats func() []PoolStat) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("fin_api_db_pool_"+name, help, []string{"shard", "role"}, nil)
+	}
+	return &poolCollector{
+		stats:             stats,
+		acquiredConns:     desc("acquired_conns", "Connections currently checked out of the pool."),
+		idleConns:         desc("idle_conns", "Idle connections in the pool."),
+		totalConns:        desc("total_conns", "Open connections in the pool."),
+		maxConns:          desc("max_conns", "Configured maximum pool size."),
+		acquireCount:      desc("acquire_total", "Successful connection acquires."),
+		emptyAcquireCount: desc("acquire_wait_total", "Acquires that had to wait for a connection."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		canceledAcquires:  desc("acquire_canceled_total", "Acquires canceled by their context."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquires
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range c.stats() {
+		labels := []string{s.Shard, s.Role}
+		gauge := func(desc *prometheus.Desc, value float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+		}
+		counter := func(desc *prometheus.Desc, value float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value, labels...)
+		}
+
+		gauge(c.acquiredConns, float64(s.Stat.AcquiredConns()))
+		gauge(c.idleConns, float64(s.Stat.IdleConns()))
+		gauge(c.totalConns, float64(s.Stat.TotalConns()))
+		gauge(c.maxConns, float64(s.Stat.MaxConns()))
+		counter(c.acquireCount, float64(s.Stat.AcquireCount()))
+		counter(c.emptyAcquireCount, float64(s.Stat.EmptyAcquireCount()))
+		counter(c.acquireDuration, s.Stat.AcquireDuration().Seconds())
+		counter(c.canceledAcquires, float64(s.Stat.CanceledAcquireCount()))
+	}
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-shared/config"
+)
+
+func TestPoolConfigWithDefaults(t *testing.T) {
+	cfg := config.PoolConfig{MaxConns: 40, MaxConnIdleTime: time.Minute}.WithDefaults(defaultPoolConfig)
+
+	assert.Equal(t, int32(40), cfg.MaxConns)
+	assert.Equal(t, time.Minute, cfg.MaxConnIdleTime)
+	assert.Equal(t, defaultPoolConfig.MinConns, cfg.MinConns)
+	assert.Equal(t, defaultPoolConfig.MaxConnLifetime, cfg.MaxConnLifetime)
+	assert.Equal(t, defaultPoolConfig.ConnectTimeout, cfg.ConnectTimeout)
+}
+
+func TestNewShardPoolAppliesConfig(t *testing.T) {
+	// pgxpool connects in the background, so no server is needed.
+	pool, err := newShardPool(context.Background(), "postgres://u:p@127.0.0.1:1/db", config.PoolConfig{MaxConns: 3})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	assert.Equal(t, int32(3), pool.Config().MaxConns)
+	assert.Equal(t, defaultPoolConfig.MaxConnLifetime, pool.Config().MaxConnLifetime)
+}
+
+func TestPoolCollector(t *testing.T) {
+	pool, err := newShardPool(context.Background(), "postgres://u:p@127.0.0.1:1/db", config.PoolConfig{MaxConns: 7})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	collector := NewPoolCollector(func() []PoolStat {
+		return []PoolStat{{Shard: "shard0", Role: "primary", Stat: pool.Stat()}}
+	})
+
+	expected := `
+# HELP fin_api_db_pool_max_conns Configured maximum pool size.
+# TYPE fin_api_db_pool_max_conns gauge
+fin_api_db_pool_max_conns{role="primary",shard="shard0"} 7
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "fin_api_db_pool_max_conns"))
+	assert.Equal(t, 8, testutil.CollectAndCount(collector))
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultMaxReplicaLag        = 5 * time.Second
+	defaultLagCheckInterval     = 5 * time.Second
+	defaultReadYourWritesWindow = 10 * time.Second
+)
+
+// replicaLagQuery reports zero lag for a replica that has replayed
+// everything it received, so an idle primary doesn't make replicas look
+// stale.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+type replica struct {
+	pool *pgxpool.Pool
+	// lag is the last measured replication lag, or -1 if the last check
+	// failed.
+	lag atomic.Int64
+}
+
+func newReplica(pool *pgxpool.Pool) *replica {
+	r := &replica{pool: pool}
+	r.lag.Store(-1)
+	return r
+}
+
+func (r *replica) usable(maxLag time.Duration) bool {
+	lag := r.lag.Load()
+	return lag >= 0 && time.Duration(lag) <= maxLag
+}
+
+func (r *replica) checkLag(ctx context.Context) {
+	var seconds float64
+	if err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		r.lag.Store(-1)
+		return
+	}
+	r.lag.Store(int64(seconds * float64(time.Second)))
+}
+
+// pickReplica round-robins over replicas whose lag is within maxLag.
+func (s *shard) pickReplica(maxLag time.Duration) *replica {
+	n := len(s.replicas)
+	start := s.next.Add(1)
+	for i := 0; i < n; i++ {
+		r := s.replicas[(start+uint64(i))%uint64(n)]
+		if r.usable(maxLag) {
+			return r
+		}
+	}
+	return nil
+}
+
+// MarkWrite records a mutation for the user so that their reads through
+// this instance go to the primary for replicas.read_your_writes_window.
+// Other instances don't see the mark.
+func (bm *BucketManager) MarkWrite(userID int) {
+	if len(bm.GetBucketForUser(userID).shard.replicas) == 0 {
+		return
+	}
+	bm.recentWrites.Store(userID, time.Now())
+}
+
+func (bm *BucketManager) wroteRecently(userID int) bool {
+	value, ok := bm.recentWrites.Load(userID)
+	if !ok {
+		return false
+	}
+	if time.Since(value.(time.Time)) < bm.readYourWritesWindow() {
+		return true
+	}
+	bm.recentWrites.CompareAndDelete(userID, value)
+	return false
+}
+
+func (bm *BucketManager) maxLag() time.Duration {
+	if bm.replicaCfg.MaxLag > 0 {
+		return bm.replicaCfg.MaxLag
+	}
+	return defaultMaxReplicaLag
+}
+
+func (bm *BucketManager) readYourWritesWindow() time.Duration {
+	if bm.replicaCfg.ReadYourWritesWindow > 0 {
+		return bm.replicaCfg.ReadYourWritesWindow
+	}
+	return defaultReadYourWritesWindow
+}
+
+func (bm *BucketManager) monitorReplicas(ctx context.Context) {
+	interval := bm.replicaCfg.LagCheckInterval
+	if interval <= 0 {
+		interval = defaultLagCheckInterval
+	}
+
+	var replicas []*replica
+	for _, s := range bm.shards {
+		replicas = append(replicas, s.replicas...)
+	}
+	if len(replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, r := range replicas {
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			r.checkLag(checkCtx)
+			cancel()
+		}
+		bm.forgetOldWrites()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (bm *BucketManager) forgetOldWrites() {
+	window := bm.readYourWritesWindow()
+	bm.recentWrites.Range(func(key, value any) bool {
+		if time.Since(value.(time.Time)) >= window {
+			bm.recentWrites.CompareAndDelete(key, value)
+		}
+		return true
+	})
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+
+	"fin-api/internal/domain"
+	"fin-shared/config"
+)
+
+func newTestBucketManager(replicaLags ...time.Duration) *BucketManager {
+	s := &shard{name: "shard0", primary: new(pgxpool.Pool), breaker: newBreaker(1)}
+	for _, lag := range replicaLags {
+		r := newReplica(new(pgxpool.Pool))
+		r.lag.Store(int64(lag))
+		s.replicas = append(s.replicas, r)
+	}
+
+	bucket := &BucketInfo{ShardName: s.name, Pool: s.primary, shard: s}
+	return &BucketManager{
+		shards:       []*shard{s},
+		buckets:      []*BucketInfo{bucket},
+		bucketsByKey: map[bucketKey]*BucketInfo{{0, 0}: bucket},
+		totalBuckets: 1,
+		replicaCfg: config.ReplicaConfig{
+			MaxLag:               time.Second,
+			ReadYourWritesWindow: time.Minute,
+		},
+	}
+}
+
+func TestGetReadPoolForUserUsesFreshReplica(t *testing.T) {
+	bm := newTestBucketManager(10*time.Second, 100*time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		assert.Same(t, bm.shards[0].replicas[1].pool, readPoolFor(t, bm, 1))
+	}
+}
+
+func TestGetReadPoolForUserFallsBackToPrimary(t *testing.T) {
+
+	t.Run("no replicas", func(t *testing.T) {
+		bm := newTestBucketManager()
+		assert.Same(t, bm.shards[0].primary, readPoolFor(t, bm, 1))
+	})
+
+	t.Run("all replicas lagging or down", func(t *testing.T) {
+		bm := newTestBucketManager(10*time.Second, -1)
+		assert.Same(t, bm.shards[0].primary, readPoolFor(t, bm, 1))
+	})
+
+	t.Run("read your writes", func(t *testing.T) {
+		bm := newTestBucketManager(0)
+		bm.MarkWrite(1)
+		assert.Same(t, bm.shards[0].primary, readPoolFor(t, bm, 1))
+		assert.Same(t, bm.shards[0].replicas[0].pool, readPoolFor(t, bm, 2))
+	})
+}
+
+func readPoolFor(t *testing.T, bm *BucketManager, userID int) *pgxpool.Pool {
+	t.Helper()
+	pool, err := bm.GetReadPoolForUser(userID)
+	assert.NoError(t, err)
+	return pool
+}
+
+func TestOpenBreakerFailsFast(t *testing.T) {
+	bm := newTestBucketManager()
+	bm.shards[0].breaker.failure(errors.New("connection refused"))
+
+	_, err := bm.GetWritePoolForUser(1)
+	assert.ErrorIs(t, err, domain.ErrShardUnavailable)
+	_, err = bm.GetReadPoolForUser(1)
+	assert.ErrorIs(t, err, domain.ErrShardUnavailable)
+
+	status := bm.ShardStatuses()[0]
+	assert.Equal(t, ShardUnavailable, status.State)
+	assert.Equal(t, "connection refused", status.LastError)
+
+	bm.shards[0].breaker.success()
+	_, err = bm.GetWritePoolForUser(1)
+	assert.NoError(t, err)
+}
+
+func TestOpenBreakerKeepsFreshReplicaReadable(t *testing.T) {
+	bm := newTestBucketManager(0)
+	bm.shards[0].breaker.failure(errors.New("connection refused"))
+
+	assert.Same(t, bm.shards[0].replicas[0].pool, readPoolFor(t, bm, 1))
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultScatterConcurrency = 4
+
+// BucketFunc runs a query against a single bucket. pool is a replica within
+// replicas.max_lag when one is available, otherwise the shard primary.
+type BucketFunc func(ctx context.Context, bucket *BucketInfo, pool *pgxpool.Pool) error
+
+// ForEachBucket runs fn for every configured bucket in parallel, with at
+// most concurrency buckets in flight. The first error cancels the remaining
+// calls and is returned. fn is called from several goroutines, so merging
+// its results is up to the caller.
+func (bm *BucketManager) ForEachBucket(ctx context.Context, concurrency int, fn BucketFunc) error {
+	if concurrency <= 0 {
+		concurrency = defaultScatterConcurrency
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for _, bucket := range bm.buckets {
+		g.Go(func() error {
+			pool, err := bm.readPool(bucket)
+			if err != nil {
+				return err
+			}
+			if err := fn(ctx, bucket, pool); err != nil {
+				return fmt.Errorf("%s on %s: %w", bucket.Schema(), bucket.ShardName, err)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func (bm *BucketManager) readPool(bucket *BucketInfo) (*pgxpool.Pool, error) {
+	if r := bucket.shard.pickReplica(bm.maxLag()); r != nil {
+		return r.pool, nil
+	}
+	return bucket.WritePool()
+}
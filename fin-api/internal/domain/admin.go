@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type DailyVolume struct {
+	Day               time.Time `json:"day"`
+	TotalIncome       float64   `json:"total_income"`
+	TotalExpense      float64   `json:"total_expense"`
+	TransactionsCount int       `json:"transactions_count"`
+	ActiveUsers       int       `json:"active_users"`
+}
+
+type GlobalStats struct {
+	From              time.Time     `json:"from"`
+	To                time.Time     `json:"to"`
+	TotalIncome       float64       `json:"total_income"`
+	TotalExpense      float64       `json:"total_expense"`
+	TransactionsCount int           `json:"transactions_count"`
+	ActiveUsers       int           `json:"active_users"`
+	Days              []DailyVolume `json:"days"`
+}
+
+type ActiveUser struct {
+	UserID            int       `json:"user_id"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	TransactionsCount int       `json:"transactions_count"`
+}
+
+type ActiveUsers struct {
+	Since time.Time    `json:"since"`
+	Total int          `json:"total"`
+	Users []ActiveUser `json:"users"`
+}
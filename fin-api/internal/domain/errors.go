@@ -3,6 +3,8 @@ package domain
 import "errors"
 
 var (
-	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrTransactionNotFound    = errors.New("transaction not found")
 	ErrInvalidTransactionType = errors.New("invalid transaction type")
+	ErrShardUnavailable       = errors.New("shard unavailable")
+	ErrInvalidArgument        = errors.New("invalid argument")
 )
@@ -11,6 +11,10 @@ const (
 	TransactionTypeExpense TransactionType = "expense"
 )
 
+func (t TransactionType) Valid() bool {
+	return t == TransactionTypeIncome || t == TransactionTypeExpense
+}
+
 type Transaction struct {
 	ID        int64           `json:"id"`
 	UserID    int             `json:"user_id"`
@@ -20,9 +24,60 @@ type Transaction struct {
 	CreatedAt time.Time       `json:"created_at"`
 }
 
+// TransactionFilter narrows a listing of a user's transactions. Zero
+// fields match every transaction; From is inclusive and To exclusive.
+type TransactionFilter struct {
+	Type     TransactionType
+	Category string
+	From     time.Time
+	To       time.Time
+}
+
+// TransactionCursor is the position of a transaction in the newest-first
+// order used by paginated listings.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// TransactionBatch is part of a user's transactions as of Version, the
+// user's version when they were read.
+type TransactionBatch struct {
+	Version      int64
+	Transactions []Transaction
+}
+
+type TransactionPage struct {
+	Transactions  []Transaction
+	NextPageToken string
+}
+
+// TransactionMessage is published after every write to a user's
+// transactions. It carries the write, not the user's transactions:
+// consumers apply it to what they derived from the earlier ones.
 type TransactionMessage struct {
-	UserID       int           `json:"user_id"`
-	Transactions []Transaction `json:"transactions"`
+	UserID int `json:"user_id"`
+	// Version is the user's version after Change. It counts the user's
+	// writes, so consumers can drop events that arrive after a newer one
+	// and tell from a gap that they missed one.
+	Version int64              `json:"version"`
+	Change  *TransactionChange `json:"change"`
+}
+
+type ChangeOp string
+
+const (
+	ChangeCreate ChangeOp = "create"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// TransactionChange describes a single write. Transaction is the created,
+// updated or deleted transaction; Previous is set on updates only.
+type TransactionChange struct {
+	Op          ChangeOp     `json:"op"`
+	Transaction Transaction  `json:"transaction"`
+	Previous    *Transaction `json:"previous,omitempty"`
 }
 
 type FinanceStats struct {
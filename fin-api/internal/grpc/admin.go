@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"fin-api/internal/service"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+)
+
+// AdminServer implements fintrackv1.AdminService. It is not registered on
+// the gRPC port: the HTTP server serves it through the gateway, behind the
+// admin scope and the admin_api feature toggle.
+type AdminServer struct {
+	fintrackv1.UnimplementedAdminServiceServer
+	service *service.AdminService
+}
+
+func NewAdminServer(service *service.AdminService) *AdminServer {
+	return &AdminServer{service: service}
+}
+
+func (s *AdminServer) GetGlobalStats(ctx context.Context, req *fintrackv1.GlobalStatsRequest) (*fintrackv1.GlobalStats, error) {
+	from, err := parseDateOrTime("from", req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDateOrTime("to", req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.service.GlobalStats(ctx, from, to)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	days := make([]*fintrackv1.DailyVolume, 0, len(stats.Days))
+	for _, day := range stats.Days {
+		days = append(days, &fintrackv1.DailyVolume{
+			Day:               day.Day.Format(time.RFC3339Nano),
+			TotalIncome:       day.TotalIncome,
+			TotalExpense:      day.TotalExpense,
+			TransactionsCount: int64(day.TransactionsCount),
+			ActiveUsers:       int64(day.ActiveUsers),
+		})
+	}
+	return &fintrackv1.GlobalStats{
+		From:              stats.From.Format(time.RFC3339Nano),
+		To:                stats.To.Format(time.RFC3339Nano),
+		TotalIncome:       stats.TotalIncome,
+		TotalExpense:      stats.TotalExpense,
+		TransactionsCount: int64(stats.TransactionsCount),
+		ActiveUsers:       int64(stats.ActiveUsers),
+		Days:              days,
+	}, nil
+}
+
+func (s *AdminServer) ListActiveUsers(ctx context.Context, req *fintrackv1.ActiveUsersRequest) (*fintrackv1.ActiveUsers, error) {
+	since, err := parseDateOrTime("active_since", req.GetActiveSince())
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.service.ActiveUsers(ctx, since, int(req.GetLimit()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	users := make([]*fintrackv1.ActiveUser, 0, len(active.Users))
+	for _, user := range active.Users {
+		users = append(users, &fintrackv1.ActiveUser{
+			UserId:            int64(user.UserID),
+			LastActivityAt:    user.LastActivityAt.Format(time.RFC3339Nano),
+			TransactionsCount: int64(user.TransactionsCount),
+		})
+	}
+	return &fintrackv1.ActiveUsers{
+		Since: active.Since.Format(time.RFC3339Nano),
+		Total: int64(active.Total),
+		Users: users,
+	}, nil
+}
+
+// parseDateOrTime accepts plain dates as well as RFC3339 timestamps, so
+// that admins can write ?from=2026-03-01.
+func parseDateOrTime(name, value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return parseTime(name, value)
+}
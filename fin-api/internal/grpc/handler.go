@@ -4,32 +4,200 @@ import (
 	"context"
 	"time"
 
-	"fin-api/api/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"fin-api/internal/domain"
+	fintrackv1 "fin-shared/api/fintrack/v1"
 )
 
-func (s *Server) GetUserTransactions(ctx context.Context, req *proto.UserRequest) (*proto.UserTransactions, error) {
-	items, err := s.service.ListTransactions(ctx, int(req.GetUserId()))
+func (s *Server) GetUserTransactions(ctx context.Context, req *fintrackv1.UserRequest) (*fintrackv1.UserTransactions, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.UserTransactions{
+	items, err := s.service.ListTransactions(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &fintrackv1.UserTransactions{
 		Transactions: convertDomainTransactions(items),
 	}, nil
 }
 
-func convertDomainTransactions(items []domain.Transaction) []*proto.Transaction {
-	result := make([]*proto.Transaction, 0, len(items))
+// streamBatchSize keeps each StreamUserTransactions message far below the
+// default 4MB limit.
+const streamBatchSize = 500
+
+func (s *Server) StreamUserTransactions(req *fintrackv1.UserRequest, stream fintrackv1.TransactionService_StreamUserTransactionsServer) error {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.StreamTransactions(stream.Context(), userID, 0, streamBatchSize, func(batch domain.TransactionBatch) error {
+		// v1 has no version to send with the empty batch of a user
+		// without transactions.
+		if len(batch.Transactions) == 0 {
+			return nil
+		}
+		return stream.Send(&fintrackv1.UserTransactions{Transactions: convertDomainTransactions(batch.Transactions)})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return statusError(err)
+	}
+	return nil
+}
+
+func (s *Server) CreateTransaction(ctx context.Context, req *fintrackv1.CreateTransactionRequest) (*fintrackv1.Transaction, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	txType, err := transactionTypeFromRequest(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.service.CreateTransaction(ctx, domain.Transaction{
+		UserID:   userID,
+		Amount:   req.GetAmount(),
+		Category: req.GetCategory(),
+		Type:     txType,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertDomainTransaction(created), nil
+}
+
+func (s *Server) GetTransaction(ctx context.Context, req *fintrackv1.TransactionRequest) (*fintrackv1.Transaction, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.service.GetTransaction(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertDomainTransaction(tx), nil
+}
+
+func (s *Server) UpdateTransaction(ctx context.Context, req *fintrackv1.UpdateTransactionRequest) (*fintrackv1.Transaction, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	txType, err := transactionTypeFromRequest(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.service.UpdateTransaction(ctx, domain.Transaction{
+		ID:       req.GetId(),
+		UserID:   userID,
+		Amount:   req.GetAmount(),
+		Category: req.GetCategory(),
+		Type:     txType,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertDomainTransaction(updated), nil
+}
+
+func (s *Server) DeleteTransaction(ctx context.Context, req *fintrackv1.TransactionRequest) (*fintrackv1.DeleteTransactionResponse, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTransaction(ctx, userID, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &fintrackv1.DeleteTransactionResponse{}, nil
+}
+
+func (s *Server) ListTransactions(ctx context.Context, req *fintrackv1.ListTransactionsRequest) (*fintrackv1.ListTransactionsResponse, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseTime("from", req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseTime("to", req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.ListTransactionsPage(ctx, userID, domain.TransactionFilter{
+		Type:     domain.TransactionType(req.GetType()),
+		Category: req.GetCategory(),
+		From:     from,
+		To:       to,
+	}, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &fintrackv1.ListTransactionsResponse{
+		Transactions:  convertDomainTransactions(page.Transactions),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// userIDFromRequest is the user_id check of every v1 and v2 RPC.
+func userIDFromRequest(id int64) (int, error) {
+	if id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "user_id must be positive")
+	}
+	return int(id), nil
+}
+
+func transactionTypeFromRequest(value string) (domain.TransactionType, error) {
+	txType := domain.TransactionType(value)
+	if !txType.Valid() {
+		return "", status.Error(codes.InvalidArgument, "type must be income or expense")
+	}
+	return txType, nil
+}
+
+func parseTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s: %v", name, err)
+	}
+	return t, nil
+}
+
+// convertDomainTransaction keeps the sub-second part of created_at, which
+// the REST API has always returned.
+func convertDomainTransaction(tx domain.Transaction) *fintrackv1.Transaction {
+	return &fintrackv1.Transaction{
+		Id:        tx.ID,
+		UserId:    int64(tx.UserID),
+		Amount:    tx.Amount,
+		Category:  tx.Category,
+		Type:      string(tx.Type),
+		CreatedAt: tx.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func convertDomainTransactions(items []domain.Transaction) []*fintrackv1.Transaction {
+	result := make([]*fintrackv1.Transaction, 0, len(items))
 	for _, tx := range items {
-		result = append(result, &proto.Transaction{
-			Id:        tx.ID,
-			UserId:    int64(tx.UserID),
-			Amount:    tx.Amount,
-			Category:  tx.Category,
-			Type:      string(tx.Type),
-			CreatedAt: tx.CreatedAt.Format(time.RFC3339),
-		})
+		result = append(result, convertDomainTransaction(tx))
 	}
 	return result
 }
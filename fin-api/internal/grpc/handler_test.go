@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fin-api/internal/domain"
+	kafkamocks "fin-api/internal/kafka/mocks"
+	repomocks "fin-api/internal/repository/mocks"
+	"fin-api/internal/service"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+)
+
+func newTestServer(t *testing.T) (*Server, *repomocks.TransactionRepository, *kafkamocks.EventPublisher) {
+	repo := repomocks.NewTransactionRepository(t)
+	publisher := kafkamocks.NewEventPublisher(t)
+	return &Server{service: service.NewTransactionService(repo, publisher)}, repo, publisher
+}
+
+func TestCreateTransaction(t *testing.T) {
+	s, repo, publisher := newTestServer(t)
+	ctx := context.Background()
+	createdAt := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	repo.On("CreateTransaction", ctx, domain.Transaction{UserID: 1, Amount: 10, Category: "food", Type: domain.TransactionTypeExpense}).
+		Return(domain.Transaction{ID: 5, UserID: 1, Amount: 10, Category: "food", Type: domain.TransactionTypeExpense, CreatedAt: createdAt}, int64(1), nil)
+	publisher.On("PublishTransactions", ctx, mock.Anything).Return(nil)
+
+	tx, err := s.CreateTransaction(ctx, &fintrackv1.CreateTransactionRequest{UserId: 1, Amount: 10, Category: "food", Type: "expense"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), tx.GetId())
+	assert.Equal(t, "2026-03-01T12:00:00Z", tx.GetCreatedAt())
+}
+
+func TestTransactionRPCStatusCodes(t *testing.T) {
+	s, repo, _ := newTestServer(t)
+	ctx := context.Background()
+
+	repo.On("GetTransaction", ctx, 1, int64(404)).Return(domain.Transaction{}, domain.ErrTransactionNotFound)
+	repo.On("UpdateTransaction", ctx, mock.Anything).Return(domain.Transaction{}, domain.Transaction{}, int64(0), domain.ErrTransactionNotFound)
+	repo.On("DeleteTransaction", ctx, 2, int64(1)).Return(domain.Transaction{}, int64(0), domain.ErrShardUnavailable)
+
+	tests := map[string]struct {
+		call func() error
+		code codes.Code
+	}{
+		"create with unknown type": {
+			call: func() error {
+				_, err := s.CreateTransaction(ctx, &fintrackv1.CreateTransactionRequest{UserId: 1, Type: "refund"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		"create without user": {
+			call: func() error {
+				_, err := s.CreateTransaction(ctx, &fintrackv1.CreateTransactionRequest{Type: "income"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		"list all without user": {
+			call: func() error {
+				_, err := s.GetUserTransactions(ctx, &fintrackv1.UserRequest{UserId: -1})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		"get missing": {
+			call: func() error {
+				_, err := s.GetTransaction(ctx, &fintrackv1.TransactionRequest{UserId: 1, Id: 404})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		"update missing": {
+			call: func() error {
+				_, err := s.UpdateTransaction(ctx, &fintrackv1.UpdateTransactionRequest{UserId: 1, Id: 404, Type: "income"})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		"delete on unavailable shard": {
+			call: func() error {
+				_, err := s.DeleteTransaction(ctx, &fintrackv1.TransactionRequest{UserId: 2, Id: 1})
+				return err
+			},
+			code: codes.Unavailable,
+		},
+		"list with bad time": {
+			call: func() error {
+				_, err := s.ListTransactions(ctx, &fintrackv1.ListTransactionsRequest{UserId: 1, From: "yesterday"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		"list with bad token": {
+			call: func() error {
+				_, err := s.ListTransactions(ctx, &fintrackv1.ListTransactionsRequest{UserId: 1, PageToken: "%%"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(tt.call()))
+		})
+	}
+}
+
+func TestListTransactionsPassesFilter(t *testing.T) {
+	s, repo, _ := newTestServer(t)
+	ctx := context.Background()
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.TransactionFilter{Type: domain.TransactionTypeIncome, Category: "salary", From: from}
+
+	repo.On("QueryUserTransactions", ctx, 1, filter, (*domain.TransactionCursor)(nil), 11).
+		Return([]domain.Transaction{{ID: 1, UserID: 1, Type: domain.TransactionTypeIncome, CreatedAt: from}}, nil)
+
+	resp, err := s.ListTransactions(ctx, &fintrackv1.ListTransactionsRequest{
+		UserId:   1,
+		Type:     "income",
+		Category: "salary",
+		From:     "2026-03-01T00:00:00Z",
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetTransactions(), 1)
+	assert.Empty(t, resp.GetNextPageToken())
+}
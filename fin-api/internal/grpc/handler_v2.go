@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fin-api/internal/domain"
+	"fin-api/internal/service"
+	fintrackv2 "fin-shared/api/fintrack/v2"
+)
+
+// serverV2 implements fintrack.v2.TransactionService on the same service
+// as v1. The two versions differ only in how fields are encoded on the
+// wire, so page tokens work across them.
+type serverV2 struct {
+	fintrackv2.UnimplementedTransactionServiceServer
+	service *service.TransactionService
+}
+
+func (s *serverV2) CreateTransaction(ctx context.Context, req *fintrackv2.CreateTransactionRequest) (*fintrackv2.Transaction, error) {
+	tx, err := transactionFromV2(req.GetUserId(), 0, req.GetAmount(), req.GetCategory(), req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.service.CreateTransaction(ctx, tx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertTransactionV2(created), nil
+}
+
+func (s *serverV2) GetTransaction(ctx context.Context, req *fintrackv2.TransactionRequest) (*fintrackv2.Transaction, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.service.GetTransaction(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertTransactionV2(tx), nil
+}
+
+func (s *serverV2) UpdateTransaction(ctx context.Context, req *fintrackv2.UpdateTransactionRequest) (*fintrackv2.Transaction, error) {
+	tx, err := transactionFromV2(req.GetUserId(), req.GetId(), req.GetAmount(), req.GetCategory(), req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.service.UpdateTransaction(ctx, tx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertTransactionV2(updated), nil
+}
+
+func (s *serverV2) DeleteTransaction(ctx context.Context, req *fintrackv2.TransactionRequest) (*fintrackv2.DeleteTransactionResponse, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTransaction(ctx, userID, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &fintrackv2.DeleteTransactionResponse{}, nil
+}
+
+func (s *serverV2) ListTransactions(ctx context.Context, req *fintrackv2.ListTransactionsRequest) (*fintrackv2.ListTransactionsResponse, error) {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.TransactionFilter{Category: req.GetCategory()}
+	if req.GetType() != fintrackv2.TransactionType_TRANSACTION_TYPE_UNSPECIFIED {
+		if filter.Type, err = transactionTypeFromV2(req.GetType()); err != nil {
+			return nil, err
+		}
+	}
+	if req.From != nil {
+		if err := req.GetFrom().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "from: %v", err)
+		}
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		if err := req.GetTo().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "to: %v", err)
+		}
+		filter.To = req.GetTo().AsTime()
+	}
+
+	page, err := s.service.ListTransactionsPage(ctx, userID, filter, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &fintrackv2.ListTransactionsResponse{
+		Transactions:  convertTransactionsV2(page.Transactions),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (s *serverV2) StreamUserTransactions(req *fintrackv2.UserRequest, stream fintrackv2.TransactionService_StreamUserTransactionsServer) error {
+	userID, err := userIDFromRequest(req.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.StreamTransactions(stream.Context(), userID, req.GetMinVersion(), streamBatchSize, func(batch domain.TransactionBatch) error {
+		return stream.Send(&fintrackv2.TransactionBatch{
+			Transactions: convertTransactionsV2(batch.Transactions),
+			Version:      batch.Version,
+		})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return statusError(err)
+	}
+	return nil
+}
+
+func transactionFromV2(userID, id int64, amount *fintrackv2.Money, category string, txType fintrackv2.TransactionType) (domain.Transaction, error) {
+	uid, err := userIDFromRequest(userID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	value, err := moneyFromV2(amount)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	t, err := transactionTypeFromV2(txType)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return domain.Transaction{ID: id, UserID: uid, Amount: value, Category: category, Type: t}, nil
+}
+
+func transactionTypeFromV2(t fintrackv2.TransactionType) (domain.TransactionType, error) {
+	switch t {
+	case fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME:
+		return domain.TransactionTypeIncome, nil
+	case fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE:
+		return domain.TransactionTypeExpense, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unsupported transaction type %s", t)
+	}
+}
+
+func transactionTypeToV2(t domain.TransactionType) fintrackv2.TransactionType {
+	switch t {
+	case domain.TransactionTypeIncome:
+		return fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME
+	case domain.TransactionTypeExpense:
+		return fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE
+	default:
+		return fintrackv2.TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+	}
+}
+
+// maxMoneyUnits is the largest units whose amount in cents, 99 cents
+// included, still fits in an int64.
+const maxMoneyUnits = (math.MaxInt64 - 99) / 100
+
+// moneyFromV2 accepts amounts with at most two decimal places, which is
+// what the amount column stores.
+func moneyFromV2(m *fintrackv2.Money) (float64, error) {
+	if m == nil {
+		return 0, status.Error(codes.InvalidArgument, "amount is required")
+	}
+	units, nanos := m.GetUnits(), int64(m.GetNanos())
+	if units > maxMoneyUnits || units < -maxMoneyUnits {
+		return 0, status.Errorf(codes.InvalidArgument, "amount: units must be within ±%d", int64(maxMoneyUnits))
+	}
+	if nanos <= -1e9 || nanos >= 1e9 || (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return 0, status.Error(codes.InvalidArgument, "amount: nanos must be within ±999999999 and have the sign of units")
+	}
+	if nanos%1e7 != 0 {
+		return 0, status.Error(codes.InvalidArgument, "amount: at most two decimal places are supported")
+	}
+	return float64(units*100+nanos/1e7) / 100, nil
+}
+
+func moneyToV2(amount float64) *fintrackv2.Money {
+	cents := int64(math.Round(amount * 100))
+	return &fintrackv2.Money{Units: cents / 100, Nanos: int32(cents%100) * 1e7}
+}
+
+func convertTransactionV2(tx domain.Transaction) *fintrackv2.Transaction {
+	return &fintrackv2.Transaction{
+		Id:        tx.ID,
+		UserId:    int64(tx.UserID),
+		Amount:    moneyToV2(tx.Amount),
+		Category:  tx.Category,
+		Type:      transactionTypeToV2(tx.Type),
+		CreatedAt: timestamppb.New(tx.CreatedAt),
+	}
+}
+
+func convertTransactionsV2(items []domain.Transaction) []*fintrackv2.Transaction {
+	result := make([]*fintrackv2.Transaction, 0, len(items))
+	for _, tx := range items {
+		result = append(result, convertTransactionV2(tx))
+	}
+	return result
+}
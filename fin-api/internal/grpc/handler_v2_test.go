@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fin-api/internal/domain"
+	fintrackv2 "fin-shared/api/fintrack/v2"
+)
+
+func TestMoneyV2(t *testing.T) {
+	for _, amount := range []float64{0, 12.34, -12.34, 0.05, 1000000.99} {
+		value, err := moneyFromV2(moneyToV2(amount))
+		require.NoError(t, err)
+		assert.Equal(t, amount, value)
+	}
+
+	assert.Equal(t, &fintrackv2.Money{Units: -1, Nanos: -500000000}, moneyToV2(-1.5))
+
+	for _, m := range []*fintrackv2.Money{
+		{Units: maxMoneyUnits, Nanos: 990000000},
+		{Units: -maxMoneyUnits, Nanos: -990000000},
+	} {
+		value, err := moneyFromV2(m)
+		require.NoError(t, err)
+		assert.Equal(t, float64(m.GetUnits()), math.Trunc(value), "the amount keeps its sign")
+	}
+
+	for name, m := range map[string]*fintrackv2.Money{
+		"missing":                        nil,
+		"mixed signs":                    {Units: 1, Nanos: -10000000},
+		"negative units, positive nanos": {Units: -1, Nanos: 10000000},
+		"nanos overflow":                 {Units: 1, Nanos: 1000000000},
+		"units overflow":                 {Units: maxMoneyUnits + 1},
+		"negative units overflow":        {Units: -maxMoneyUnits - 1},
+		"max int64 units":                {Units: math.MaxInt64, Nanos: 990000000},
+		"min int64 units":                {Units: math.MinInt64, Nanos: -990000000},
+		"sub-cent nanos":                 {Units: 1, Nanos: 1},
+		"three decimals":                 {Nanos: 1000000},
+	} {
+		_, err := moneyFromV2(m)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
+
+func TestCreateTransactionV2(t *testing.T) {
+	s, repo, publisher := newTestServer(t)
+	v2 := &serverV2{service: s.service}
+	ctx := context.Background()
+	createdAt := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	repo.On("CreateTransaction", ctx, domain.Transaction{UserID: 1, Amount: 12.5, Category: "food", Type: domain.TransactionTypeExpense}).
+		Return(domain.Transaction{ID: 5, UserID: 1, Amount: 12.5, Category: "food", Type: domain.TransactionTypeExpense, CreatedAt: createdAt}, int64(1), nil)
+	publisher.On("PublishTransactions", ctx, mock.Anything).Return(nil)
+
+	tx, err := v2.CreateTransaction(ctx, &fintrackv2.CreateTransactionRequest{
+		UserId:   1,
+		Amount:   &fintrackv2.Money{Units: 12, Nanos: 500000000},
+		Category: "food",
+		Type:     fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fintrackv2.TransactionType_TRANSACTION_TYPE_EXPENSE, tx.GetType())
+	assert.Equal(t, createdAt, tx.GetCreatedAt().AsTime())
+
+	_, err = v2.CreateTransaction(ctx, &fintrackv2.CreateTransactionRequest{
+		UserId: 1,
+		Amount: &fintrackv2.Money{Units: 1},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListTransactionsV2Filter(t *testing.T) {
+	s, repo, _ := newTestServer(t)
+	v2 := &serverV2{service: s.service}
+	ctx := context.Background()
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.TransactionFilter{Type: domain.TransactionTypeIncome, From: from}
+
+	repo.On("QueryUserTransactions", ctx, 1, filter, (*domain.TransactionCursor)(nil), 51).Return(nil, nil)
+
+	_, err := v2.ListTransactions(ctx, &fintrackv2.ListTransactionsRequest{
+		UserId: 1,
+		Type:   fintrackv2.TransactionType_TRANSACTION_TYPE_INCOME,
+		From:   timestamppb.New(from),
+	})
+	require.NoError(t, err)
+
+	_, err = v2.ListTransactions(ctx, &fintrackv2.ListTransactionsRequest{
+		UserId: 1,
+		To:     &timestamppb.Timestamp{Nanos: -1},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -1,40 +1,151 @@
 package grpc
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fin-api/internal/service"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	stdgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 
-	"fin-api/api/proto"
+	"fin-api/internal/metrics"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	fintrackv2 "fin-shared/api/fintrack/v2"
+	"fin-shared/health"
+	"fin-shared/logging"
 )
 
+const readinessInterval = 5 * time.Second
+
+// keepaliveMinTime is the shortest client keepalive interval accepted;
+// it matches the minimum grpc_client.keepalive.time in the config.
+const keepaliveMinTime = 10 * time.Second
+
 type Server struct {
-	proto.UnimplementedTransactionServiceServer
+	fintrackv1.UnimplementedTransactionServiceServer
 	service *service.TransactionService
+	v2      *serverV2
 	server  *stdgrpc.Server
+	health  *grpchealth.Server
+	ready   *health.Checker
+	stop    chan struct{}
+	once    sync.Once
 }
 
-func NewServer(service *service.TransactionService) *Server {
+func NewServer(service *service.TransactionService, m *metrics.Metrics, ready *health.Checker, tlsConfig *tls.Config) *Server {
 	return &Server{
 		service: service,
-		server:  stdgrpc.NewServer(),
+		v2:      &serverV2{service: service},
+		server: stdgrpc.NewServer(
+			serverCreds(tlsConfig),
+			// Clients such as fin-analytics ping idle connections to
+			// detect dead instances; without a policy the server would
+			// answer a ping more often than every 5 minutes with GOAWAY.
+			stdgrpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             keepaliveMinTime,
+				PermitWithoutStream: true,
+			}),
+			stdgrpc.StatsHandler(otelgrpc.NewServerHandler()),
+			stdgrpc.ChainUnaryInterceptor(m.UnaryServerInterceptor(), logging.UnaryServerInterceptor()),
+			stdgrpc.ChainStreamInterceptor(m.StreamServerInterceptor(), logging.StreamServerInterceptor()),
+		),
+		health: grpchealth.NewServer(),
+		ready:  ready,
+		stop:   make(chan struct{}),
+	}
+}
+
+// serverCreds serves plaintext when tlsConfig is nil. With client_auth
+// the config also verifies the client certificate.
+func serverCreds(tlsConfig *tls.Config) stdgrpc.ServerOption {
+	if tlsConfig == nil {
+		return stdgrpc.Creds(insecure.NewCredentials())
 	}
+	return stdgrpc.Creds(credentials.NewTLS(tlsConfig))
 }
 
+// Start serves on addr until Stop is called.
 func (s *Server) Start(addr string) error {
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return fmt.Errorf("listen grpc: %w", err)
 	}
 
-	proto.RegisterTransactionServiceServer(s.server, s)
-	return s.server.Serve(listener)
+	fintrackv1.RegisterTransactionServiceServer(s.server, s)
+	fintrackv2.RegisterTransactionServiceServer(s.server, s.v2)
+	healthpb.RegisterHealthServer(s.server, s.health)
+
+	go s.watchReadiness()
+
+	if err := s.server.Serve(listener); !errors.Is(err, stdgrpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// watchReadiness mirrors the HTTP readiness checks into the standard gRPC
+// health service, both for the server as a whole ("") and for each
+// version of TransactionService.
+func (s *Server) watchReadiness() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if s.ready.Run(ctx).Status == health.StatusFail {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.health.SetServingStatus("", status)
+		s.health.SetServingStatus(fintrackv1.TransactionService_ServiceDesc.ServiceName, status)
+		s.health.SetServingStatus(fintrackv2.TransactionService_ServiceDesc.ServiceName, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
-func (s *Server) Stop() {
-	if s.server != nil {
+// Stop reports NOT_SERVING to health-checking clients and waits for
+// in-flight RPCs to finish. Once ctx expires the remaining RPCs are
+// cancelled.
+func (s *Server) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
 		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-done
+		return ctx.Err()
 	}
 }
@@ -0,0 +1,32 @@
+package http
+
+import (
+	stdhttp "net/http"
+	"strings"
+)
+
+// requireScope only lets through requests that carry an API key with the
+// scope in the Authorization: Bearer header.
+func (s *Server) requireScope(scope string) func(stdhttp.Handler) stdhttp.Handler {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				httpError(w, stdhttp.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			principal, ok := s.auth.Authenticate(token)
+			if !ok {
+				httpError(w, stdhttp.StatusUnauthorized, "invalid token")
+				return
+			}
+			if !principal.HasScope(scope) {
+				httpError(w, stdhttp.StatusForbidden, "missing scope "+scope)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
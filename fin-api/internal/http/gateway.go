@@ -0,0 +1,191 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stdhttp "net/http"
+	"reflect"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	fintrackv1 "fin-shared/api/fintrack/v1"
+)
+
+// successCodes overrides the 200 the gateway answers with, keyed by full
+// gRPC method name.
+var successCodes = map[string]int{
+	"/fintrack.v1.TransactionService/CreateTransaction": stdhttp.StatusCreated,
+	"/fintrack.v1.TransactionService/DeleteTransaction": stdhttp.StatusNoContent,
+}
+
+// newGateway serves the google.api.http bindings of the v1 protos by
+// calling the servers in-process. Routing, authentication and rate
+// limiting stay in the chi router in front of it.
+func newGateway(transactions fintrackv1.TransactionServiceServer, admin fintrackv1.AdminServiceServer) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, int64Numbers{&runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}}),
+		runtime.WithErrorHandler(gatewayError),
+		runtime.WithRoutingErrorHandler(gatewayRoutingError),
+		runtime.WithForwardResponseOption(setSuccessCode),
+	)
+
+	ctx := context.Background()
+	if err := fintrackv1.RegisterTransactionServiceHandlerServer(ctx, mux, transactions); err != nil {
+		return nil, err
+	}
+	if err := fintrackv1.RegisterAdminServiceHandlerServer(ctx, mux, admin); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// int64Numbers writes the int64 fields whose openapiv2_field option
+// declares them integers as JSON numbers, as the REST API did before the
+// gateway, so the spec and the wire agree. Other int64 fields, transaction
+// IDs among them, stay the strings protojson writes: they exceed 2^53 and a
+// JavaScript client would round them. Requests are decoded by protojson,
+// which accepts both.
+type int64Numbers struct {
+	*runtime.JSONPb
+}
+
+func (m int64Numbers) Marshal(v any) ([]byte, error) {
+	raw, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	desc, list := messageDescriptor(v)
+	if desc == nil {
+		return raw, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if items, ok := decoded.([]any); ok && list {
+		for _, item := range items {
+			unquoteInt64s(desc, item)
+		}
+	} else {
+		unquoteInt64s(desc, decoded)
+	}
+	return json.Marshal(decoded)
+}
+
+// messageDescriptor returns the descriptor of v, or of its elements when v
+// is a slice of messages, as for a response_body that is a repeated field.
+func messageDescriptor(v any) (desc protoreflect.MessageDescriptor, list bool) {
+	if msg, ok := v.(proto.Message); ok {
+		return msg.ProtoReflect().Descriptor(), false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	if msg, ok := reflect.Zero(rv.Type().Elem()).Interface().(proto.Message); ok {
+		return msg.ProtoReflect().Descriptor(), true
+	}
+	return nil, false
+}
+
+// unquoteInt64s replaces the quoted 64-bit integers documented as numbers
+// in the message desc in its decoded JSON object v, recursing into nested
+// messages.
+func unquoteInt64s(desc protoreflect.MessageDescriptor, v any) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		value, ok := obj[string(field.Name())]
+		if !ok {
+			continue
+		}
+		switch {
+		case field.IsMap():
+			if entries, ok := value.(map[string]any); ok {
+				for key, entry := range entries {
+					entries[key] = unquoteValue(field.MapValue(), entry)
+				}
+			}
+		case field.IsList():
+			if items, ok := value.([]any); ok {
+				for j, item := range items {
+					items[j] = unquoteValue(field, item)
+				}
+			}
+		default:
+			obj[string(field.Name())] = unquoteValue(field, value)
+		}
+	}
+}
+
+func unquoteValue(field protoreflect.FieldDescriptor, v any) any {
+	switch field.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if s, ok := v.(string); ok && documentedInteger(field) {
+			return json.Number(s)
+		}
+	case protoreflect.MessageKind:
+		unquoteInt64s(field.Message(), v)
+	}
+	return v
+}
+
+// documentedInteger reports whether the openapiv2_field option of field
+// gives it the JSON schema type integer.
+func documentedInteger(field protoreflect.FieldDescriptor) bool {
+	schema, ok := proto.GetExtension(field.Options(), options.E_Openapiv2Field).(*options.JSONSchema)
+	if !ok || schema == nil {
+		return false
+	}
+	for _, t := range schema.GetType() {
+		if t == options.JSONSchema_INTEGER {
+			return true
+		}
+	}
+	return false
+}
+
+// gatewayError answers with the HTTP status of the gRPC code and the same
+// {"error": ...} body as the rest of the router.
+func gatewayError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w stdhttp.ResponseWriter, _ *stdhttp.Request, err error) {
+	st := status.Convert(err)
+	httpError(w, runtime.HTTPStatusFromCode(st.Code()), st.Message())
+}
+
+func gatewayRoutingError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w stdhttp.ResponseWriter, _ *stdhttp.Request, httpStatus int) {
+	httpError(w, httpStatus, strings.ToLower(stdhttp.StatusText(httpStatus)))
+}
+
+func setSuccessCode(ctx context.Context, w stdhttp.ResponseWriter, _ proto.Message) error {
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		return nil
+	}
+	code, ok := successCodes[method]
+	if !ok {
+		return nil
+	}
+	if code == stdhttp.StatusNoContent {
+		w.Header().Del("Content-Type")
+	}
+	w.WriteHeader(code)
+	return nil
+}
@@ -2,52 +2,80 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	stdhttp "net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
-	"fin-api/internal/domain"
+	"fin-api/internal/metrics"
+	"fin-api/internal/ratelimit"
 	"fin-api/internal/swagger"
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	"fin-shared/auth"
+	"fin-shared/config"
+	"fin-shared/health"
+	"fin-shared/logging"
+	"fin-shared/tracing"
 )
 
-type TransactionService interface {
-	CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)
-	ListTransactions(ctx context.Context, userID int) ([]domain.Transaction, error)
-	UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)
-	DeleteTransaction(ctx context.Context, userID int, transactionID int64) error
+// Features reports runtime feature toggles.
+type Features interface {
+	Enabled(name string) bool
 }
 
 type Server struct {
-	service TransactionService
-	router  *chi.Mux
-	server  *stdhttp.Server
+	gateway  *runtime.ServeMux
+	ready    *health.Checker
+	auth     *auth.Authenticator
+	limiter  *ratelimit.Limiter
+	features Features
+	metrics  *metrics.Metrics
+	router   *chi.Mux
+	server   *stdhttp.Server
 }
 
-func NewServer(service TransactionService) *Server {
+// NewServer serves transactions and admin, the gRPC servers, as REST on
+// the routes from their google.api.http annotations.
+func NewServer(transactions fintrackv1.TransactionServiceServer, admin fintrackv1.AdminServiceServer, ready *health.Checker, authenticator *auth.Authenticator, limiter *ratelimit.Limiter, features Features, tlsConfig *tls.Config, m *metrics.Metrics) (*Server, error) {
+	gateway, err := newGateway(transactions, admin)
+	if err != nil {
+		return nil, fmt.Errorf("register gateway: %w", err)
+	}
+
 	router := chi.NewRouter()
+	router.Use(tracing.HTTPMiddleware)
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
-	router.Use(middleware.Logger)
+	router.Use(logging.HTTPMiddleware)
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.Timeout(60 * time.Second))
+	router.Use(m.HTTPMiddleware)
 
 	s := &Server{
-		service: service,
-		router:  router,
+		gateway:  gateway,
+		ready:    ready,
+		auth:     authenticator,
+		limiter:  limiter,
+		features: features,
+		metrics:  m,
+		router:   router,
+		server:   &stdhttp.Server{Handler: router, TLSConfig: tlsConfig},
 	}
 
 	s.routes()
-	return s
+	return s, nil
 }
 
 func (s *Server) routes() {
-	s.router.Get("/healthz", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
-		w.WriteHeader(stdhttp.StatusOK)
-		_, _ = w.Write([]byte(`{"status":"ok"}`))
-	})
+	s.router.Method(stdhttp.MethodGet, "/healthz", health.LivenessHandler())
+	s.router.Method(stdhttp.MethodGet, "/livez", health.LivenessHandler())
+	s.router.Method(stdhttp.MethodGet, "/readyz", s.ready.Handler())
+	s.router.Method(stdhttp.MethodGet, "/metrics", s.metrics.Handler())
 
 	s.router.Route("/swagger", func(r chi.Router) {
 		r.Get("/", swagger.UIHandler("/swagger/spec"))
@@ -55,38 +83,46 @@ func (s *Server) routes() {
 	})
 
 	s.router.Route("/v1/users/{userID}", func(r chi.Router) {
-		r.Post("/transactions", s.handleCreateTransaction)
-		r.Get("/transactions", s.handleListTransactions)
-		r.Put("/transactions/{transactionID}", s.handleUpdateTransaction)
-		r.Delete("/transactions/{transactionID}", s.handleDeleteTransaction)
+		r.Use(logging.UserIDFromURL)
+		r.Use(s.rateLimit)
+		// The gateway routes on the full path and method; the patterns
+		// here only name the routes in metrics and logs.
+		r.Handle("/transactions", s.gateway)
+		r.Handle("/transactions:page", s.gateway)
+		r.Handle("/transactions/{transactionID}", s.gateway)
+	})
+
+	s.router.Route("/admin", func(r chi.Router) {
+		r.Use(s.requireFeature(config.FeatureAdminAPI))
+		r.Use(s.requireScope(auth.ScopeAdmin))
+		r.Handle("/stats/global", s.gateway)
+		r.Handle("/users", s.gateway)
 	})
 }
 
-func (s *Server) Start(ctx context.Context, addr string) error {
-	s.server = &stdhttp.Server{
-		Addr:    addr,
-		Handler: s.router,
+// Start serves on addr until Stop is called. Calling Stop first makes
+// Start return immediately.
+func (s *Server) Start(addr string) error {
+	s.server.Addr = addr
+	var err error
+	if s.server.TLSConfig != nil {
+		// The certificate comes from TLSConfig, not from files.
+		err = s.server.ListenAndServeTLS("", "")
+	} else {
+		err = s.server.ListenAndServe()
 	}
-
-	errCh := make(chan error, 1)
-	go func() {
-		errCh <- s.server.ListenAndServe()
-	}()
-
-	select {
-	case <-ctx.Done():
-		return s.Stop(context.Background())
-	case err := <-errCh:
-		if errors.Is(err, stdhttp.ErrServerClosed) {
-			return nil
-		}
+	if !errors.Is(err, stdhttp.ErrServerClosed) {
 		return err
 	}
+	return nil
 }
 
+// Stop closes the listener and waits for in-flight requests until ctx
+// expires, then drops the connections that are still open.
 func (s *Server) Stop(ctx context.Context) error {
-	if s.server == nil {
-		return nil
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return err
 	}
-	return s.server.Shutdown(ctx)
+	return nil
 }
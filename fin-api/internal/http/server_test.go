@@ -0,0 +1,153 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"fin-api/internal/domain"
+	finapigrpc "fin-api/internal/grpc"
+	kafkamocks "fin-api/internal/kafka/mocks"
+	"fin-api/internal/metrics"
+	"fin-api/internal/ratelimit"
+	repomocks "fin-api/internal/repository/mocks"
+	"fin-api/internal/service"
+	"fin-shared/auth"
+	"fin-shared/config"
+	"fin-shared/health"
+)
+
+type features map[string]bool
+
+func (f features) Enabled(name string) bool { return f[name] }
+
+func newTestServer(t *testing.T) (*httptest.Server, *repomocks.TransactionRepository, *kafkamocks.EventPublisher, *repomocks.AdminRepository) {
+	repo := repomocks.NewTransactionRepository(t)
+	publisher := kafkamocks.NewEventPublisher(t)
+	adminRepo := repomocks.NewAdminRepository(t)
+	m := metrics.New()
+	ready := health.NewChecker(time.Second)
+
+	s, err := NewServer(
+		finapigrpc.NewServer(service.NewTransactionService(repo, publisher), m, ready, nil),
+		finapigrpc.NewAdminServer(service.NewAdminService(adminRepo)),
+		ready,
+		auth.New([]config.APIKeyConfig{{Name: "ops", Key: "secret", Scopes: []string{auth.ScopeAdmin}}}),
+		ratelimit.New(0, 0),
+		features{config.FeatureAdminAPI: true},
+		nil,
+		m,
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(s.router)
+	t.Cleanup(server.Close)
+	return server, repo, publisher, adminRepo
+}
+
+func do(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+	req, err := stdhttp.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := stdhttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(raw)
+}
+
+func TestGatewayTransactionRoutes(t *testing.T) {
+	server, repo, publisher, _ := newTestServer(t)
+	createdAt := time.Date(2026, time.March, 1, 12, 0, 0, 123456000, time.UTC)
+	// Above 2^53, so it must stay a string: a float64 on the way would
+	// round it.
+	const id = 1152921504606846977
+	tx := domain.Transaction{ID: id, UserID: 1, Amount: 10, Category: "food", Type: domain.TransactionTypeExpense, CreatedAt: createdAt}
+
+	repo.On("CreateTransaction", mock.Anything, domain.Transaction{UserID: 1, Amount: 10, Category: "food", Type: domain.TransactionTypeExpense}).
+		Return(tx, int64(1), nil)
+	repo.On("ListUserTransactions", mock.Anything, 1).Return([]domain.Transaction{tx}, nil)
+	repo.On("QueryUserTransactions", mock.Anything, 1, domain.TransactionFilter{Type: domain.TransactionTypeExpense}, (*domain.TransactionCursor)(nil), 2).
+		Return([]domain.Transaction{tx}, nil)
+	repo.On("UpdateTransaction", mock.Anything, domain.Transaction{ID: id, UserID: 1, Amount: 10, Category: "food", Type: domain.TransactionTypeExpense}).
+		Return(tx, tx, int64(2), nil).Twice()
+	repo.On("DeleteTransaction", mock.Anything, 1, int64(id)).Return(tx, int64(4), nil)
+	publisher.On("PublishTransactions", mock.Anything, mock.Anything).Return(nil)
+
+	code, body := do(t, stdhttp.MethodPost, server.URL+"/v1/users/1/transactions", `{"amount":10,"category":"food","type":"expense","unknown":true}`)
+	assert.Equal(t, stdhttp.StatusCreated, code)
+	assert.JSONEq(t, `{"id":"1152921504606846977","user_id":1,"amount":10,"category":"food","type":"expense","created_at":"2026-03-01T12:00:00.123456Z"}`, body)
+
+	code, body = do(t, stdhttp.MethodGet, server.URL+"/v1/users/1/transactions", "")
+	assert.Equal(t, stdhttp.StatusOK, code)
+	assert.JSONEq(t, `[{"id":"1152921504606846977","user_id":1,"amount":10,"category":"food","type":"expense","created_at":"2026-03-01T12:00:00.123456Z"}]`, body)
+
+	code, body = do(t, stdhttp.MethodGet, server.URL+"/v1/users/1/transactions:page?type=expense&page_size=1", "")
+	assert.Equal(t, stdhttp.StatusOK, code)
+	assert.JSONEq(t, `{"transactions":[{"id":"1152921504606846977","user_id":1,"amount":10,"category":"food","type":"expense","created_at":"2026-03-01T12:00:00.123456Z"}],"next_page_token":""}`, body)
+
+	for _, method := range []string{stdhttp.MethodPut, stdhttp.MethodPatch} {
+		code, body = do(t, method, server.URL+"/v1/users/1/transactions/1152921504606846977", `{"amount":10,"category":"food","type":"expense"}`)
+		assert.Equal(t, stdhttp.StatusOK, code, method)
+		assert.JSONEq(t, `{"id":"1152921504606846977","user_id":1,"amount":10,"category":"food","type":"expense","created_at":"2026-03-01T12:00:00.123456Z"}`, body, method)
+	}
+
+	code, body = do(t, stdhttp.MethodDelete, server.URL+"/v1/users/1/transactions/1152921504606846977", "")
+	assert.Equal(t, stdhttp.StatusNoContent, code)
+	assert.Empty(t, body)
+}
+
+func TestGatewayErrors(t *testing.T) {
+	server, repo, _, _ := newTestServer(t)
+	repo.On("GetTransaction", mock.Anything, 1, int64(404)).Return(domain.Transaction{}, domain.ErrTransactionNotFound)
+
+	tests := map[string]struct {
+		method, path, body string
+		code               int
+	}{
+		"not found":          {stdhttp.MethodGet, "/v1/users/1/transactions/404", "", stdhttp.StatusNotFound},
+		"invalid type":       {stdhttp.MethodPost, "/v1/users/1/transactions", `{"type":"refund"}`, stdhttp.StatusBadRequest},
+		"invalid id":         {stdhttp.MethodGet, "/v1/users/1/transactions/abc", "", stdhttp.StatusBadRequest},
+		"user not positive":  {stdhttp.MethodGet, "/v1/users/0/transactions", "", stdhttp.StatusBadRequest},
+		"invalid payload":    {stdhttp.MethodPut, "/v1/users/1/transactions/1", `{`, stdhttp.StatusBadRequest},
+		"method not routed":  {stdhttp.MethodPost, "/v1/users/1/transactions/1", `{}`, stdhttp.StatusMethodNotAllowed},
+		"admin invalid date": {stdhttp.MethodGet, "/admin/users?active_since=yesterday", "", stdhttp.StatusBadRequest},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, body := do(t, tt.method, server.URL+tt.path, tt.body)
+			assert.Equal(t, tt.code, code)
+			var payload map[string]string
+			require.NoError(t, json.Unmarshal([]byte(body), &payload))
+			assert.NotEmpty(t, payload["error"])
+		})
+	}
+}
+
+func TestGatewayAdminRoutes(t *testing.T) {
+	server, _, _, adminRepo := newTestServer(t)
+	since := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	adminRepo.On("ActiveUsers", mock.Anything, since, 100).
+		Return(domain.ActiveUsers{Since: since, Total: 1, Users: []domain.ActiveUser{{UserID: 7, LastActivityAt: since, TransactionsCount: 3}}}, nil)
+
+	code, body := do(t, stdhttp.MethodGet, server.URL+"/admin/users?active_since=2026-03-01", "")
+	assert.Equal(t, stdhttp.StatusOK, code)
+	assert.JSONEq(t, `{"since":"2026-03-01T00:00:00Z","total":1,"users":[{"user_id":7,"last_activity_at":"2026-03-01T00:00:00Z","transactions_count":3}]}`, body)
+
+	req, err := stdhttp.NewRequest(stdhttp.MethodGet, server.URL+"/admin/users", nil)
+	require.NoError(t, err)
+	resp, err := stdhttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,123 @@
+package idgen
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IDs are 63-bit Snowflake-style integers:
+//
+//	| 41 bits: ms since Epoch | 5 bits: shard | 6 bits: bucket | 4 bits: node | 7 bits: sequence |
+//
+// The shard and bucket the transaction was created in are part of the ID,
+// so an ID can be routed back to its bucket without knowing the user.
+//
+// Rows created before IDs were generated keep their SERIAL IDs, which are
+// unique per bucket only. The column was an INTEGER then, so they are all
+// below MinID and Decode rejects them.
+const (
+	timeBits   = 41
+	shardBits  = 5
+	bucketBits = 6
+	nodeBits   = 4
+	seqBits    = 7
+
+	nodeShift   = seqBits
+	bucketShift = nodeShift + nodeBits
+	shardShift  = bucketShift + bucketBits
+	timeShift   = shardShift + shardBits
+
+	MaxShards          = 1 << shardBits
+	MaxBucketsPerShard = 1 << bucketBits
+	MaxNodes           = 1 << nodeBits
+
+	maxSequence = 1<<seqBits - 1
+	maxTime     = 1<<timeBits - 1
+
+	// MinID is the smallest ID Decode accepts: one above the largest
+	// legacy SERIAL ID. Any ID generated 512ms or more past Epoch is above it.
+	MinID = 1 << 31
+)
+
+// Epoch is the zero point of the ID timestamp.
+var Epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type Generator struct {
+	mu       sync.Mutex
+	node     int64
+	lastTime int64
+	sequence int64
+	now      func() time.Time
+}
+
+func New(node int) (*Generator, error) {
+	if node < 0 || node >= MaxNodes {
+		return nil, fmt.Errorf("node id %d out of range [0, %d)", node, MaxNodes)
+	}
+	return &Generator{node: int64(node), now: time.Now}, nil
+}
+
+func (g *Generator) Next(shardIndex, bucketIndex int) (int64, error) {
+	if shardIndex < 0 || shardIndex >= MaxShards {
+		return 0, fmt.Errorf("shard index %d out of range [0, %d)", shardIndex, MaxShards)
+	}
+	if bucketIndex < 0 || bucketIndex >= MaxBucketsPerShard {
+		return 0, fmt.Errorf("bucket index %d out of range [0, %d)", bucketIndex, MaxBucketsPerShard)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now().Sub(Epoch).Milliseconds()
+	// Never go back in time if the wall clock does.
+	if now < g.lastTime {
+		now = g.lastTime
+	}
+
+	if now == g.lastTime {
+		g.sequence++
+		if g.sequence > maxSequence {
+			now++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	if now > maxTime {
+		return 0, fmt.Errorf("id timestamp overflow")
+	}
+	g.lastTime = now
+
+	return now<<timeShift |
+		int64(shardIndex)<<shardShift |
+		int64(bucketIndex)<<bucketShift |
+		g.node<<nodeShift |
+		g.sequence, nil
+}
+
+type Parts struct {
+	Time        time.Time
+	ShardIndex  int
+	BucketIndex int
+	Node        int
+	Sequence    int
+}
+
+// Decode splits an ID into its parts. ok is false for IDs that were not
+// produced by a Generator: legacy SERIAL IDs, below MinID.
+func Decode(id int64) (parts Parts, ok bool) {
+	if id < MinID {
+		return Parts{}, false
+	}
+	ms := id >> timeShift
+
+	return Parts{
+		Time:        Epoch.Add(time.Duration(ms) * time.Millisecond),
+		ShardIndex:  int(id >> shardShift & (MaxShards - 1)),
+		BucketIndex: int(id >> bucketShift & (MaxBucketsPerShard - 1)),
+		Node:        int(id >> nodeShift & (MaxNodes - 1)),
+		Sequence:    int(id & maxSequence),
+	}, true
+}
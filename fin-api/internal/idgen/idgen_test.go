@@ -0,0 +1,78 @@
+package idgen
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRoundTrip(t *testing.T) {
+	g, err := New(3)
+	require.NoError(t, err)
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	id, err := g.Next(17, 42)
+	require.NoError(t, err)
+
+	parts, ok := Decode(id)
+	require.True(t, ok)
+	assert.Equal(t, now, parts.Time)
+	assert.Equal(t, 17, parts.ShardIndex)
+	assert.Equal(t, 42, parts.BucketIndex)
+	assert.Equal(t, 3, parts.Node)
+	assert.Equal(t, 0, parts.Sequence)
+}
+
+func TestNextIsUniqueAndIncreasing(t *testing.T) {
+	g, err := New(0)
+	require.NoError(t, err)
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	var prev int64
+	for i := 0; i < 3*(maxSequence+1); i++ {
+		id, err := g.Next(1, 1)
+		require.NoError(t, err)
+		assert.Greater(t, id, prev)
+		prev = id
+	}
+
+	// The clock moving backwards must not produce smaller IDs.
+	now = now.Add(-time.Second)
+	id, err := g.Next(1, 1)
+	require.NoError(t, err)
+	assert.Greater(t, id, prev)
+}
+
+func TestNextRejectsOutOfRange(t *testing.T) {
+	g, err := New(0)
+	require.NoError(t, err)
+
+	_, err = g.Next(MaxShards, 0)
+	assert.Error(t, err)
+	_, err = g.Next(0, MaxBucketsPerShard)
+	assert.Error(t, err)
+
+	_, err = New(MaxNodes)
+	assert.Error(t, err)
+}
+
+func TestDecodeLegacyID(t *testing.T) {
+	for _, id := range []int64{-1, 0, 12345, math.MaxInt32} {
+		_, ok := Decode(id)
+		assert.False(t, ok, id)
+	}
+
+	g, err := New(0)
+	require.NoError(t, err)
+	g.now = func() time.Time { return Epoch.Add(512 * time.Millisecond) }
+	id, err := g.Next(0, 0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, id, int64(MinID), "the earliest generated ID")
+}
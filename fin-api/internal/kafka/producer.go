@@ -3,30 +3,47 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"fin-api/internal/domain"
+	"fin-api/internal/metrics"
+	"fin-shared/logging"
 )
 
 type Producer struct {
 	topic    string
+	client   sarama.Client
 	producer sarama.SyncProducer
+	metrics  *metrics.Metrics
 }
 
-func NewProducer(brokers []string, topic string) (*Producer, error) {
+func NewProducer(brokers []string, topic string, m *metrics.Metrics) (*Producer, error) {
 	cfg := sarama.NewConfig()
 	cfg.Producer.RequiredAcks = sarama.WaitForAll
 	cfg.Producer.Retry.Max = 5
 	cfg.Producer.Return.Successes = true
 
-	p, err := sarama.NewSyncProducer(brokers, cfg)
+	client, err := sarama.NewClient(brokers, cfg)
 	if err != nil {
+		return nil, fmt.Errorf("create kafka client: %w", err)
+	}
+
+	p, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
 		return nil, fmt.Errorf("create kafka producer: %w", err)
 	}
 
-	return &Producer{topic: topic, producer: p}, nil
+	return &Producer{topic: topic, client: client, producer: p, metrics: m}, nil
 }
 
 func (p *Producer) PublishTransactions(ctx context.Context, msg domain.TransactionMessage) error {
@@ -35,21 +52,63 @@ func (p *Producer) PublishTransactions(ctx context.Context, msg domain.Transacti
 		return fmt.Errorf("marshal transaction message: %w", err)
 	}
 
+	// Keyed by user, the messages of a user stay in one partition and are
+	// consumed in the order they were published.
 	kmsg := &sarama.ProducerMessage{
 		Topic: p.topic,
+		Key:   sarama.StringEncoder(strconv.Itoa(msg.UserID)),
 		Value: sarama.ByteEncoder(payload),
 	}
 
-	if _, _, err := p.producer.SendMessage(kmsg); err != nil {
+	ctx, span := otel.Tracer("fin-api/kafka").Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", p.topic),
+		),
+	)
+	defer span.End()
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{msg: kmsg})
+
+	start := time.Now()
+	partition, offset, err := p.producer.SendMessage(kmsg)
+	p.metrics.ObserveKafkaPublish(p.topic, start, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("send kafka message: %w", err)
 	}
+	span.SetAttributes(
+		attribute.Int("messaging.kafka.destination.partition", int(partition)),
+		attribute.Int64("messaging.kafka.message.offset", offset),
+	)
+	logging.FromContext(ctx).Debug("Published transactions",
+		"topic", p.topic,
+		"partition", partition,
+		"offset", offset,
+	)
 
 	return nil
 }
 
+// Ping refreshes the topic metadata, which fails when no broker is
+// reachable. sarama doesn't take a context, so callers must bound the call
+// themselves.
+func (p *Producer) Ping(_ context.Context) error {
+	if p.client.Closed() {
+		return fmt.Errorf("kafka client closed")
+	}
+	if err := p.client.RefreshMetadata(p.topic); err != nil {
+		return fmt.Errorf("refresh kafka metadata: %w", err)
+	}
+	return nil
+}
+
 func (p *Producer) Close() error {
 	if p.producer == nil {
 		return nil
 	}
-	return p.producer.Close()
+	// The producer does not own the client, so close both even if
+	// flushing the producer failed.
+	return errors.Join(p.producer.Close(), p.client.Close())
 }
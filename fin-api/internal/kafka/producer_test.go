@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"fin-api/internal/domain"
+)
+
+func TestPublishTransactionsPropagatesTraceContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var sent *sarama.ProducerMessage
+	syncProducer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	syncProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		sent = msg
+		return nil
+	})
+	producer := &Producer{topic: "user-transactions", producer: syncProducer}
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	require.NoError(t, producer.PublishTransactions(ctx, domain.TransactionMessage{}))
+	parent.End()
+
+	carrier := headerCarrier{msg: sent}
+	require.NotEmpty(t, carrier.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), carrier))
+	assert.Equal(t, parent.SpanContext().TraceID(), extracted.TraceID())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "user-transactions publish", spans[0].Name)
+	assert.Equal(t, extracted.SpanID(), spans[0].SpanContext.SpanID())
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"fin-shared/servermetrics"
+)
+
+const namespace = "fin_api"
+
+// Metrics owns the registry the service exposes on /metrics. A dedicated
+// registry (rather than the global default) lets tests scrape exactly what
+// the service registered.
+//
+// All Observe methods are safe to call on a nil *Metrics, so components
+// can be built without metrics in tests.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	server         *servermetrics.Server
+	kafkaPublishes *prometheus.HistogramVec
+	dbQueries      *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		kafkaPublishes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kafka_produce_duration_seconds",
+			Help:      "Kafka produce latency by topic and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"topic", "result"}),
+		dbQueries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Postgres query latency by shard, operation and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"shard", "operation", "result"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.kafkaPublishes,
+		m.dbQueries,
+	)
+	m.server = servermetrics.New(namespace, m.Registry)
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+func (m *Metrics) HTTPMiddleware(next http.Handler) http.Handler {
+	return m.served().HTTPMiddleware(next)
+}
+
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return m.served().UnaryServerInterceptor()
+}
+
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return m.served().StreamServerInterceptor()
+}
+
+func (m *Metrics) served() *servermetrics.Server {
+	if m == nil {
+		return nil
+	}
+	return m.server
+}
+
+func (m *Metrics) ObserveKafkaPublish(topic string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.kafkaPublishes.WithLabelValues(topic, result(err)).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveDBQuery(shard, operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.dbQueries.WithLabelValues(shard, operation, result(err)).Observe(time.Since(start).Seconds())
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
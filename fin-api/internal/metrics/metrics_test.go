@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPMiddlewareUsesRoutePattern(t *testing.T) {
+	m := New()
+	router := chi.NewRouter()
+	router.Use(m.HTTPMiddleware)
+	router.Get("/v1/users/{userID}/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	for _, path := range []string{"/v1/users/1/transactions", "/v1/users/2/transactions", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, uint64(2), histogramCount(t, m, "fin_api_http_request_duration_seconds", "route", "/v1/users/{userID}/transactions"))
+	assert.Equal(t, uint64(1), histogramCount(t, m, "fin_api_http_request_duration_seconds", "route", "unmatched"))
+}
+
+func TestUnaryServerInterceptorRecordsCode(t *testing.T) {
+	m := New()
+	info := &grpc.UnaryServerInfo{FullMethod: "/fintrack.TransactionService/GetUserTransactions"}
+
+	_, err := m.UnaryServerInterceptor()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Unavailable, "shard down")
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, uint64(1), histogramCount(t, m, "fin_api_grpc_server_handling_seconds", "code", "Unavailable"))
+}
+
+func TestStreamServerInterceptorRecordsCode(t *testing.T) {
+	m := New()
+	info := &grpc.StreamServerInfo{FullMethod: "/fintrack.v1.TransactionService/StreamUserTransactions"}
+
+	err := m.StreamServerInterceptor()(nil, nil, info, func(srv any, ss grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "client went away")
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, uint64(1), histogramCount(t, m, "fin_api_grpc_server_handling_seconds", "code", "Canceled"))
+}
+
+func TestObserveResults(t *testing.T) {
+	m := New()
+	m.ObserveDBQuery("shard0", "create", time.Now(), nil)
+	m.ObserveDBQuery("shard0", "create", time.Now(), errors.New("boom"))
+	m.ObserveKafkaPublish("transactions", time.Now(), nil)
+
+	assert.Equal(t, uint64(1), histogramCount(t, m, "fin_api_db_query_duration_seconds", "result", "error"))
+	assert.Equal(t, uint64(1), histogramCount(t, m, "fin_api_kafka_produce_duration_seconds", "topic", "transactions"))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.ObserveDBQuery("shard0", "create", time.Now(), nil)
+	m.ObserveKafkaPublish("transactions", time.Now(), nil)
+
+	handler := m.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	_, err := m.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestHandlerServesRegistry(t *testing.T) {
+	m := New()
+	m.ObserveDBQuery("shard0", "list", time.Now(), nil)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), `fin_api_db_query_duration_seconds_count{operation="list",result="ok",shard="shard0"} 1`))
+}
+
+// histogramCount gathers the registry and sums sample counts of the named
+// histogram's series whose label matches value.
+func histogramCount(t *testing.T, m *Metrics, name, label, value string) uint64 {
+	t.Helper()
+
+	families, err := m.Registry.Gather()
+	require.NoError(t, err)
+
+	var count uint64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if pair.GetName() == label && pair.GetValue() == value {
+					count += metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return count
+}
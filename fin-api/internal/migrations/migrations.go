@@ -0,0 +1,369 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"fin-api/internal/database"
+)
+
+// lockKey is the pg_advisory_lock key held on a shard while its bucket
+// schemas are migrated.
+const lockKey int64 = 0x66696e7472616b
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var filePattern = regexp.MustCompile(`^(\d+)_(\w+)\.sql$`)
+
+type Migration struct {
+	Version  int
+	Name     string
+	Checksum string
+	tmpl     *template.Template
+}
+
+func (m Migration) render(schema string) (string, error) {
+	var buf bytes.Buffer
+	if err := m.tmpl.Execute(&buf, struct{ Schema string }{Schema: schema}); err != nil {
+		return "", fmt.Errorf("render migration %d: %w", m.Version, err)
+	}
+	return buf.String(), nil
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		match := filePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		content, err := files.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+		tmpl, err := template.New(entry.Name()).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("parse migration %s: %w", entry.Name(), err)
+		}
+
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     match[2],
+			Checksum: hex.EncodeToString(sum[:]),
+			tmpl:     tmpl,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// SchemaStatus describes the migration state of a single bucket schema.
+type SchemaStatus struct {
+	Shard   string
+	Schema  string
+	Version int
+	Pending []int
+	Drift   []string
+}
+
+type Migrator struct {
+	bucketManager *database.BucketManager
+	migrations    []Migration
+}
+
+func New(bucketManager *database.BucketManager) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{bucketManager: bucketManager, migrations: migrations}, nil
+}
+
+// Up applies pending migrations to every configured bucket schema. Each
+// shard is locked with an advisory lock so that concurrent fin-api
+// instances don't race on the same schemas.
+func (m *Migrator) Up(ctx context.Context) error {
+	for _, shard := range m.shards() {
+		err := m.withShardLock(ctx, shard.pool, func(conn *pgxpool.Conn) error {
+			for _, bucket := range shard.buckets {
+				if err := m.migrateSchema(ctx, conn, bucket.Schema()); err != nil {
+					return fmt.Errorf("migrate %s on %s: %w", bucket.Schema(), shard.name, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status reports the version of every configured bucket schema, pending
+// migrations and drift: checksum mismatches, versions unknown to this
+// binary and bucket schemas that exist on a shard but are not configured.
+func (m *Migrator) Status(ctx context.Context) ([]SchemaStatus, error) {
+	var result []SchemaStatus
+
+	for _, shard := range m.shards() {
+		configured := make(map[string]bool, len(shard.buckets))
+		for _, bucket := range shard.buckets {
+			schema := bucket.Schema()
+			configured[schema] = true
+
+			status, err := m.schemaStatus(ctx, shard.pool, schema)
+			if err != nil {
+				return nil, fmt.Errorf("status %s on %s: %w", schema, shard.name, err)
+			}
+			status.Shard = shard.name
+			result = append(result, status)
+		}
+
+		existing, err := listBucketSchemas(ctx, shard.pool)
+		if err != nil {
+			return nil, fmt.Errorf("list schemas on %s: %w", shard.name, err)
+		}
+		for _, schema := range existing {
+			if !configured[schema] {
+				result = append(result, SchemaStatus{
+					Shard:  shard.name,
+					Schema: schema,
+					Drift:  []string{"schema exists but is not configured"},
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Migrator) migrateSchema(ctx context.Context, conn *pgxpool.Conn, schema string) error {
+	if err := ensureMigrationsTable(ctx, conn, schema); err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if checksum, ok := applied[migration.Version]; ok {
+			if checksum != migration.Checksum {
+				return fmt.Errorf("migration %d_%s: checksum mismatch", migration.Version, migration.Name)
+			}
+			continue
+		}
+
+		query, err := migration.render(schema)
+		if err != nil {
+			return err
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, query); err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Exec(ctx, fmt.Sprintf(`
+				INSERT INTO %s.schema_migrations (version, name, checksum)
+				VALUES ($1, $2, $3)
+			`, schema), migration.Version, migration.Name, migration.Checksum)
+			if err != nil {
+				return fmt.Errorf("record migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) schemaStatus(ctx context.Context, pool *pgxpool.Pool, schema string) (SchemaStatus, error) {
+	status := SchemaStatus{Schema: schema}
+
+	var exists bool
+	err := pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.tables
+			WHERE table_schema = $1 AND table_name = 'schema_migrations'
+		)
+	`, schema).Scan(&exists)
+	if err != nil {
+		return status, fmt.Errorf("check migrations table: %w", err)
+	}
+
+	applied := map[int]string{}
+	if exists {
+		if applied, err = appliedMigrations(ctx, pool, schema); err != nil {
+			return status, err
+		}
+	}
+
+	known := make(map[int]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+
+		checksum, ok := applied[migration.Version]
+		switch {
+		case !ok:
+			status.Pending = append(status.Pending, migration.Version)
+		case checksum != migration.Checksum:
+			status.Drift = append(status.Drift, fmt.Sprintf("migration %d_%s: checksum mismatch", migration.Version, migration.Name))
+		}
+	}
+
+	for version := range applied {
+		if version > status.Version {
+			status.Version = version
+		}
+		if !known[version] {
+			status.Drift = append(status.Drift, fmt.Sprintf("migration %d is applied but unknown", version))
+		}
+	}
+	sort.Strings(status.Drift)
+
+	return status, nil
+}
+
+type shardBuckets struct {
+	name    string
+	pool    *pgxpool.Pool
+	buckets []*database.BucketInfo
+}
+
+func (m *Migrator) shards() []shardBuckets {
+	var result []shardBuckets
+	for _, bucket := range m.bucketManager.Buckets() {
+		if len(result) == 0 || result[len(result)-1].pool != bucket.Pool {
+			result = append(result, shardBuckets{name: bucket.ShardName, pool: bucket.Pool})
+		}
+		last := &result[len(result)-1]
+		last.buckets = append(last.buckets, bucket)
+	}
+	return result
+}
+
+func (m *Migrator) withShardLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}()
+
+	return fn(conn)
+}
+
+func ensureMigrationsTable(ctx context.Context, conn *pgxpool.Conn, schema string) error {
+	_, err := conn.Exec(ctx, fmt.Sprintf(`
+		CREATE SCHEMA IF NOT EXISTS %[1]s;
+		CREATE TABLE IF NOT EXISTS %[1]s.schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`, schema))
+	if err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+	return nil
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func appliedMigrations(ctx context.Context, q querier, schema string) (map[int]string, error) {
+	rows, err := q.Query(ctx, fmt.Sprintf(`
+		SELECT version, checksum
+		FROM %s.schema_migrations
+	`, schema))
+	if err != nil {
+		return nil, fmt.Errorf("query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]string)
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[version] = checksum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return applied, nil
+}
+
+func listBucketSchemas(ctx context.Context, pool *pgxpool.Pool) ([]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT schema_name
+		FROM information_schema.schemata
+		WHERE schema_name ~ '^bucket_[0-9]+_[0-9]+$'
+		ORDER BY schema_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query schemas: %w", err)
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("scan schema: %w", err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return schemas, nil
+}
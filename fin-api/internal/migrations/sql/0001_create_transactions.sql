@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS {{.Schema}}.transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    amount NUMERIC(14,2) NOT NULL,
+    category TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('income', 'expense')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS {{.Schema}}_transactions_user_id_idx
+    ON {{.Schema}}.transactions (user_id);
+
+CREATE INDEX IF NOT EXISTS {{.Schema}}_transactions_created_at_idx
+    ON {{.Schema}}.transactions (created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE {{.Schema}}.transactions
+    ALTER COLUMN id TYPE BIGINT,
+    ALTER COLUMN id DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS {{.Schema}}.transactions_id_seq;
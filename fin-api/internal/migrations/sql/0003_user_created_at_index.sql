@@ -0,0 +1,3 @@
+-- Serves the newest-first, keyset-paginated ListTransactions queries.
+CREATE INDEX IF NOT EXISTS {{.Schema}}_transactions_user_created_at_idx
+    ON {{.Schema}}.transactions (user_id, created_at DESC, id DESC);
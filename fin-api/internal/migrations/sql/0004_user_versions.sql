@@ -0,0 +1,7 @@
+-- Counts the writes to each user's transactions. Every write bumps the
+-- user's row in its own transaction; the row lock orders concurrent
+-- writes, so versions are gap-free and follow the commit order.
+CREATE TABLE IF NOT EXISTS {{.Schema}}.user_versions (
+    user_id INTEGER PRIMARY KEY,
+    version BIGINT NOT NULL
+);
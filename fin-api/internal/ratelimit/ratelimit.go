@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleTTL is how long a key's bucket is kept after its last request. A
+// bucket that has been idle this long is full again anyway.
+const idleTTL = 10 * time.Minute
+
+// Limiter keeps a token bucket per key, such as a user ID. The rate can be
+// changed at runtime and applies to existing buckets too.
+type Limiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// New returns a limiter allowing requestsPerSecond per key with the given
+// burst. A zero rate disables limiting.
+func New(requestsPerSecond float64, burst int) *Limiter {
+	l := &Limiter{buckets: make(map[string]*bucket), swept: time.Now()}
+	l.SetLimit(requestsPerSecond, burst)
+	return l
+}
+
+func (l *Limiter) SetLimit(requestsPerSecond float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = rate.Limit(requestsPerSecond)
+	l.burst = burst
+	if l.limit == 0 {
+		clear(l.buckets)
+		return
+	}
+
+	now := time.Now()
+	for _, b := range l.buckets {
+		b.limiter.SetLimitAt(now, l.limit)
+		b.limiter.SetBurstAt(now, l.burst)
+	}
+}
+
+// Allow reports whether a request for key may proceed now.
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit == 0 {
+		return true
+	}
+
+	now := time.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.seen = now
+	return b.limiter.AllowN(now, 1)
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < idleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.seen) >= idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowLimitsEachKeySeparately(t *testing.T) {
+	l := New(1, 2)
+
+	assert.True(t, l.Allow("1"))
+	assert.True(t, l.Allow("1"))
+	assert.False(t, l.Allow("1"))
+	assert.True(t, l.Allow("2"))
+}
+
+func TestZeroRateDisablesLimiting(t *testing.T) {
+	l := New(0, 0)
+	for range 100 {
+		assert.True(t, l.Allow("1"))
+	}
+}
+
+func TestSetLimitAppliesToExistingBuckets(t *testing.T) {
+	l := New(1, 1)
+	assert.True(t, l.Allow("1"))
+	assert.False(t, l.Allow("1"))
+
+	l.SetLimit(0, 0)
+	assert.True(t, l.Allow("1"))
+
+	l.SetLimit(1, 3)
+	assert.True(t, l.Allow("1"))
+	assert.True(t, l.Allow("1"))
+	assert.True(t, l.Allow("1"))
+	assert.False(t, l.Allow("1"))
+}
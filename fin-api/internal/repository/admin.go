@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"fin-api/internal/database"
+	"fin-api/internal/domain"
+)
+
+type AdminRepository interface {
+	GlobalStats(ctx context.Context, from, to time.Time) (domain.GlobalStats, error)
+	ActiveUsers(ctx context.Context, since time.Time, limit int) (domain.ActiveUsers, error)
+}
+
+// PostgresAdminRepository answers platform-wide questions by fanning out to
+// every bucket and merging the per-bucket results. The directory refuses
+// routes that would move a user away from the bucket holding their
+// transactions, so each user's rows live in one bucket and per-bucket
+// distinct user counts add up exactly.
+type PostgresAdminRepository struct {
+	bucketManager *database.BucketManager
+	concurrency   int
+}
+
+func NewAdminRepository(bucketManager *database.BucketManager, concurrency int) *PostgresAdminRepository {
+	return &PostgresAdminRepository{bucketManager: bucketManager, concurrency: concurrency}
+}
+
+func (r *PostgresAdminRepository) GlobalStats(ctx context.Context, from, to time.Time) (domain.GlobalStats, error) {
+	stats := domain.GlobalStats{From: from, To: to}
+	days := make(map[time.Time]*domain.DailyVolume)
+	var mu sync.Mutex
+
+	err := r.bucketManager.ForEachBucket(ctx, r.concurrency, func(ctx context.Context, bucket *database.BucketInfo, pool *pgxpool.Pool) error {
+		// The grand total row (day IS NULL) carries the distinct user count
+		// for the whole period.
+		query := fmt.Sprintf(`
+			SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day,
+			       COALESCE(SUM(amount) FILTER (WHERE type = 'income'), 0),
+			       COALESCE(SUM(amount) FILTER (WHERE type = 'expense'), 0),
+			       COUNT(*),
+			       COUNT(DISTINCT user_id)
+			FROM %s.transactions
+			WHERE created_at >= $1 AND created_at < $2
+			GROUP BY GROUPING SETS ((date_trunc('day', created_at AT TIME ZONE 'UTC')), ())
+		`, bucket.Schema())
+
+		rows, err := pool.Query(ctx, query, from, to)
+		if err != nil {
+			return fmt.Errorf("query daily volume: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var day *time.Time
+			var volume domain.DailyVolume
+			if err := rows.Scan(&day, &volume.TotalIncome, &volume.TotalExpense, &volume.TransactionsCount, &volume.ActiveUsers); err != nil {
+				return fmt.Errorf("scan daily volume: %w", err)
+			}
+
+			mu.Lock()
+			if day == nil {
+				stats.TotalIncome += volume.TotalIncome
+				stats.TotalExpense += volume.TotalExpense
+				stats.TransactionsCount += volume.TransactionsCount
+				stats.ActiveUsers += volume.ActiveUsers
+			} else {
+				key := day.UTC()
+				merged, ok := days[key]
+				if !ok {
+					merged = &domain.DailyVolume{Day: key}
+					days[key] = merged
+				}
+				merged.TotalIncome += volume.TotalIncome
+				merged.TotalExpense += volume.TotalExpense
+				merged.TransactionsCount += volume.TransactionsCount
+				merged.ActiveUsers += volume.ActiveUsers
+			}
+			mu.Unlock()
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("rows error: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.GlobalStats{}, err
+	}
+
+	stats.Days = make([]domain.DailyVolume, 0, len(days))
+	for _, day := range days {
+		stats.Days = append(stats.Days, *day)
+	}
+	sort.Slice(stats.Days, func(i, j int) bool {
+		return stats.Days[i].Day.Before(stats.Days[j].Day)
+	})
+
+	return stats, nil
+}
+
+func (r *PostgresAdminRepository) ActiveUsers(ctx context.Context, since time.Time, limit int) (domain.ActiveUsers, error) {
+	result := domain.ActiveUsers{Since: since}
+	var mu sync.Mutex
+
+	err := r.bucketManager.ForEachBucket(ctx, r.concurrency, func(ctx context.Context, bucket *database.BucketInfo, pool *pgxpool.Pool) error {
+		// Each bucket returns its own top users; the global top is among them.
+		query := fmt.Sprintf(`
+			SELECT user_id, MAX(created_at), COUNT(*), COUNT(*) OVER ()
+			FROM %s.transactions
+			WHERE created_at >= $1
+			GROUP BY user_id
+			ORDER BY MAX(created_at) DESC
+			LIMIT $2
+		`, bucket.Schema())
+
+		rows, err := pool.Query(ctx, query, since, limit)
+		if err != nil {
+			return fmt.Errorf("query active users: %w", err)
+		}
+		defer rows.Close()
+
+		var users []domain.ActiveUser
+		total := 0
+		for rows.Next() {
+			var user domain.ActiveUser
+			if err := rows.Scan(&user.UserID, &user.LastActivityAt, &user.TransactionsCount, &total); err != nil {
+				return fmt.Errorf("scan active user: %w", err)
+			}
+			users = append(users, user)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("rows error: %w", err)
+		}
+
+		mu.Lock()
+		result.Total += total
+		result.Users = append(result.Users, users...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return domain.ActiveUsers{}, err
+	}
+
+	sort.Slice(result.Users, func(i, j int) bool {
+		return result.Users[i].LastActivityAt.After(result.Users[j].LastActivityAt)
+	})
+	if len(result.Users) > limit {
+		result.Users = result.Users[:limit]
+	}
+	if result.Users == nil {
+		result.Users = []domain.ActiveUser{}
+	}
+
+	return result, nil
+}
@@ -7,10 +7,182 @@ package mocks
 import (
 	"context"
 	"fin-api/internal/domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewAdminRepository creates a new instance of AdminRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminRepository {
+	mock := &AdminRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminRepository is an autogenerated mock type for the AdminRepository type
+type AdminRepository struct {
+	mock.Mock
+}
+
+type AdminRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminRepository) EXPECT() *AdminRepository_Expecter {
+	return &AdminRepository_Expecter{mock: &_m.Mock}
+}
+
+// ActiveUsers provides a mock function for the type AdminRepository
+func (_mock *AdminRepository) ActiveUsers(ctx context.Context, since time.Time, limit int) (domain.ActiveUsers, error) {
+	ret := _mock.Called(ctx, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveUsers")
+	}
+
+	var r0 domain.ActiveUsers
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) (domain.ActiveUsers, error)); ok {
+		return returnFunc(ctx, since, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) domain.ActiveUsers); ok {
+		r0 = returnFunc(ctx, since, limit)
+	} else {
+		r0 = ret.Get(0).(domain.ActiveUsers)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AdminRepository_ActiveUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveUsers'
+type AdminRepository_ActiveUsers_Call struct {
+	*mock.Call
+}
+
+// ActiveUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - limit int
+func (_e *AdminRepository_Expecter) ActiveUsers(ctx interface{}, since interface{}, limit interface{}) *AdminRepository_ActiveUsers_Call {
+	return &AdminRepository_ActiveUsers_Call{Call: _e.mock.On("ActiveUsers", ctx, since, limit)}
+}
+
+func (_c *AdminRepository_ActiveUsers_Call) Run(run func(ctx context.Context, since time.Time, limit int)) *AdminRepository_ActiveUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *AdminRepository_ActiveUsers_Call) Return(activeUsers domain.ActiveUsers, err error) *AdminRepository_ActiveUsers_Call {
+	_c.Call.Return(activeUsers, err)
+	return _c
+}
+
+func (_c *AdminRepository_ActiveUsers_Call) RunAndReturn(run func(ctx context.Context, since time.Time, limit int) (domain.ActiveUsers, error)) *AdminRepository_ActiveUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GlobalStats provides a mock function for the type AdminRepository
+func (_mock *AdminRepository) GlobalStats(ctx context.Context, from time.Time, to time.Time) (domain.GlobalStats, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GlobalStats")
+	}
+
+	var r0 domain.GlobalStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (domain.GlobalStats, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) domain.GlobalStats); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(domain.GlobalStats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AdminRepository_GlobalStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GlobalStats'
+type AdminRepository_GlobalStats_Call struct {
+	*mock.Call
+}
+
+// GlobalStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *AdminRepository_Expecter) GlobalStats(ctx interface{}, from interface{}, to interface{}) *AdminRepository_GlobalStats_Call {
+	return &AdminRepository_GlobalStats_Call{Call: _e.mock.On("GlobalStats", ctx, from, to)}
+}
+
+func (_c *AdminRepository_GlobalStats_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *AdminRepository_GlobalStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *AdminRepository_GlobalStats_Call) Return(globalStats domain.GlobalStats, err error) *AdminRepository_GlobalStats_Call {
+	_c.Call.Return(globalStats, err)
+	return _c
+}
+
+func (_c *AdminRepository_GlobalStats_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time) (domain.GlobalStats, error)) *AdminRepository_GlobalStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTransactionRepository creates a new instance of TransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTransactionRepository(t interface {
@@ -39,7 +211,7 @@ func (_m *TransactionRepository) EXPECT() *TransactionRepository_Expecter {
 }
 
 // CreateTransaction provides a mock function for the type TransactionRepository
-func (_mock *TransactionRepository) CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
+func (_mock *TransactionRepository) CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, int64, error) {
 	ret := _mock.Called(ctx, tx)
 
 	if len(ret) == 0 {
@@ -47,8 +219,9 @@ func (_mock *TransactionRepository) CreateTransaction(ctx context.Context, tx do
 	}
 
 	var r0 domain.Transaction
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) (domain.Transaction, error)); ok {
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) (domain.Transaction, int64, error)); ok {
 		return returnFunc(ctx, tx)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) domain.Transaction); ok {
@@ -56,12 +229,17 @@ func (_mock *TransactionRepository) CreateTransaction(ctx context.Context, tx do
 	} else {
 		r0 = ret.Get(0).(domain.Transaction)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Transaction) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Transaction) int64); ok {
 		r1 = returnFunc(ctx, tx)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.Transaction) error); ok {
+		r2 = returnFunc(ctx, tx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // TransactionRepository_CreateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransaction'
@@ -94,31 +272,46 @@ func (_c *TransactionRepository_CreateTransaction_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *TransactionRepository_CreateTransaction_Call) Return(transaction domain.Transaction, err error) *TransactionRepository_CreateTransaction_Call {
-	_c.Call.Return(transaction, err)
+func (_c *TransactionRepository_CreateTransaction_Call) Return(transaction domain.Transaction, n int64, err error) *TransactionRepository_CreateTransaction_Call {
+	_c.Call.Return(transaction, n, err)
 	return _c
 }
 
-func (_c *TransactionRepository_CreateTransaction_Call) RunAndReturn(run func(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)) *TransactionRepository_CreateTransaction_Call {
+func (_c *TransactionRepository_CreateTransaction_Call) RunAndReturn(run func(ctx context.Context, tx domain.Transaction) (domain.Transaction, int64, error)) *TransactionRepository_CreateTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteTransaction provides a mock function for the type TransactionRepository
-func (_mock *TransactionRepository) DeleteTransaction(ctx context.Context, userID int, transactionID int64) error {
+func (_mock *TransactionRepository) DeleteTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, int64, error) {
 	ret := _mock.Called(ctx, userID, transactionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTransaction")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) error); ok {
+	var r0 domain.Transaction
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) (domain.Transaction, int64, error)); ok {
+		return returnFunc(ctx, userID, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) domain.Transaction); ok {
 		r0 = returnFunc(ctx, userID, transactionID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(domain.Transaction)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int64) int64); ok {
+		r1 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, int64) error); ok {
+		r2 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // TransactionRepository_DeleteTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTransaction'
@@ -157,12 +350,84 @@ func (_c *TransactionRepository_DeleteTransaction_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *TransactionRepository_DeleteTransaction_Call) Return(err error) *TransactionRepository_DeleteTransaction_Call {
-	_c.Call.Return(err)
+func (_c *TransactionRepository_DeleteTransaction_Call) Return(transaction domain.Transaction, n int64, err error) *TransactionRepository_DeleteTransaction_Call {
+	_c.Call.Return(transaction, n, err)
+	return _c
+}
+
+func (_c *TransactionRepository_DeleteTransaction_Call) RunAndReturn(run func(ctx context.Context, userID int, transactionID int64) (domain.Transaction, int64, error)) *TransactionRepository_DeleteTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransaction provides a mock function for the type TransactionRepository
+func (_mock *TransactionRepository) GetTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, error) {
+	ret := _mock.Called(ctx, userID, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransaction")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) (domain.Transaction, error)); ok {
+		return returnFunc(ctx, userID, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) domain.Transaction); ok {
+		r0 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepository_GetTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransaction'
+type TransactionRepository_GetTransaction_Call struct {
+	*mock.Call
+}
+
+// GetTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - transactionID int64
+func (_e *TransactionRepository_Expecter) GetTransaction(ctx interface{}, userID interface{}, transactionID interface{}) *TransactionRepository_GetTransaction_Call {
+	return &TransactionRepository_GetTransaction_Call{Call: _e.mock.On("GetTransaction", ctx, userID, transactionID)}
+}
+
+func (_c *TransactionRepository_GetTransaction_Call) Run(run func(ctx context.Context, userID int, transactionID int64)) *TransactionRepository_GetTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
 	return _c
 }
 
-func (_c *TransactionRepository_DeleteTransaction_Call) RunAndReturn(run func(ctx context.Context, userID int, transactionID int64) error) *TransactionRepository_DeleteTransaction_Call {
+func (_c *TransactionRepository_GetTransaction_Call) Return(transaction domain.Transaction, err error) *TransactionRepository_GetTransaction_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *TransactionRepository_GetTransaction_Call) RunAndReturn(run func(ctx context.Context, userID int, transactionID int64) (domain.Transaction, error)) *TransactionRepository_GetTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -235,8 +500,169 @@ func (_c *TransactionRepository_ListUserTransactions_Call) RunAndReturn(run func
 	return _c
 }
 
+// QueryUserTransactions provides a mock function for the type TransactionRepository
+func (_mock *TransactionRepository) QueryUserTransactions(ctx context.Context, userID int, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	ret := _mock.Called(ctx, userID, filter, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryUserTransactions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TransactionFilter, *domain.TransactionCursor, int) ([]domain.Transaction, error)); ok {
+		return returnFunc(ctx, userID, filter, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TransactionFilter, *domain.TransactionCursor, int) []domain.Transaction); ok {
+		r0 = returnFunc(ctx, userID, filter, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.TransactionFilter, *domain.TransactionCursor, int) error); ok {
+		r1 = returnFunc(ctx, userID, filter, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepository_QueryUserTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUserTransactions'
+type TransactionRepository_QueryUserTransactions_Call struct {
+	*mock.Call
+}
+
+// QueryUserTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - filter domain.TransactionFilter
+//   - after *domain.TransactionCursor
+//   - limit int
+func (_e *TransactionRepository_Expecter) QueryUserTransactions(ctx interface{}, userID interface{}, filter interface{}, after interface{}, limit interface{}) *TransactionRepository_QueryUserTransactions_Call {
+	return &TransactionRepository_QueryUserTransactions_Call{Call: _e.mock.On("QueryUserTransactions", ctx, userID, filter, after, limit)}
+}
+
+func (_c *TransactionRepository_QueryUserTransactions_Call) Run(run func(ctx context.Context, userID int, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int)) *TransactionRepository_QueryUserTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.TransactionFilter
+		if args[2] != nil {
+			arg2 = args[2].(domain.TransactionFilter)
+		}
+		var arg3 *domain.TransactionCursor
+		if args[3] != nil {
+			arg3 = args[3].(*domain.TransactionCursor)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepository_QueryUserTransactions_Call) Return(transactions []domain.Transaction, err error) *TransactionRepository_QueryUserTransactions_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *TransactionRepository_QueryUserTransactions_Call) RunAndReturn(run func(ctx context.Context, userID int, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error)) *TransactionRepository_QueryUserTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamUserTransactions provides a mock function for the type TransactionRepository
+func (_mock *TransactionRepository) StreamUserTransactions(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error) error {
+	ret := _mock.Called(ctx, userID, minVersion, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamUserTransactions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64, int, func(domain.TransactionBatch) error) error); ok {
+		r0 = returnFunc(ctx, userID, minVersion, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TransactionRepository_StreamUserTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamUserTransactions'
+type TransactionRepository_StreamUserTransactions_Call struct {
+	*mock.Call
+}
+
+// StreamUserTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - minVersion int64
+//   - batchSize int
+//   - fn func(domain.TransactionBatch) error
+func (_e *TransactionRepository_Expecter) StreamUserTransactions(ctx interface{}, userID interface{}, minVersion interface{}, batchSize interface{}, fn interface{}) *TransactionRepository_StreamUserTransactions_Call {
+	return &TransactionRepository_StreamUserTransactions_Call{Call: _e.mock.On("StreamUserTransactions", ctx, userID, minVersion, batchSize, fn)}
+}
+
+func (_c *TransactionRepository_StreamUserTransactions_Call) Run(run func(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error)) *TransactionRepository_StreamUserTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 func(domain.TransactionBatch) error
+		if args[4] != nil {
+			arg4 = args[4].(func(domain.TransactionBatch) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepository_StreamUserTransactions_Call) Return(err error) *TransactionRepository_StreamUserTransactions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TransactionRepository_StreamUserTransactions_Call) RunAndReturn(run func(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error) error) *TransactionRepository_StreamUserTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTransaction provides a mock function for the type TransactionRepository
-func (_mock *TransactionRepository) UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
+func (_mock *TransactionRepository) UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, domain.Transaction, int64, error) {
 	ret := _mock.Called(ctx, tx)
 
 	if len(ret) == 0 {
@@ -244,8 +670,10 @@ func (_mock *TransactionRepository) UpdateTransaction(ctx context.Context, tx do
 	}
 
 	var r0 domain.Transaction
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) (domain.Transaction, error)); ok {
+	var r1 domain.Transaction
+	var r2 int64
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) (domain.Transaction, domain.Transaction, int64, error)); ok {
 		return returnFunc(ctx, tx)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Transaction) domain.Transaction); ok {
@@ -253,12 +681,22 @@ func (_mock *TransactionRepository) UpdateTransaction(ctx context.Context, tx do
 	} else {
 		r0 = ret.Get(0).(domain.Transaction)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Transaction) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Transaction) domain.Transaction); ok {
 		r1 = returnFunc(ctx, tx)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(domain.Transaction)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.Transaction) int64); ok {
+		r2 = returnFunc(ctx, tx)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.Transaction) error); ok {
+		r3 = returnFunc(ctx, tx)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
 }
 
 // TransactionRepository_UpdateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransaction'
@@ -291,12 +729,12 @@ func (_c *TransactionRepository_UpdateTransaction_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *TransactionRepository_UpdateTransaction_Call) Return(transaction domain.Transaction, err error) *TransactionRepository_UpdateTransaction_Call {
-	_c.Call.Return(transaction, err)
+func (_c *TransactionRepository_UpdateTransaction_Call) Return(updated domain.Transaction, previous domain.Transaction, version int64, err error) *TransactionRepository_UpdateTransaction_Call {
+	_c.Call.Return(updated, previous, version, err)
 	return _c
 }
 
-func (_c *TransactionRepository_UpdateTransaction_Call) RunAndReturn(run func(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)) *TransactionRepository_UpdateTransaction_Call {
+func (_c *TransactionRepository_UpdateTransaction_Call) RunAndReturn(run func(ctx context.Context, tx domain.Transaction) (domain.Transaction, domain.Transaction, int64, error)) *TransactionRepository_UpdateTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,41 +4,125 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"fin-api/internal/database"
 	"fin-api/internal/domain"
+	"fin-api/internal/idgen"
+	"fin-api/internal/metrics"
+	"fin-shared/logging"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("fin-api/repository")
+
 type PostgresTransactionRepository struct {
 	bucketManager *database.BucketManager
+	ids           *idgen.Generator
+	metrics       *metrics.Metrics
 }
 
-func NewTransactionRepository(bucketManager *database.BucketManager) *PostgresTransactionRepository {
-	return &PostgresTransactionRepository{bucketManager: bucketManager}
+func NewTransactionRepository(bucketManager *database.BucketManager, ids *idgen.Generator, m *metrics.Metrics) *PostgresTransactionRepository {
+	return &PostgresTransactionRepository{bucketManager: bucketManager, ids: ids, metrics: m}
 }
 
-func (r *PostgresTransactionRepository) CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
-	pool := r.bucketManager.GetPoolForUser(tx.UserID)
-	schema := r.bucketManager.GetBucketSchema(tx.UserID)
+// startQuery opens a client span for a query against bucket and returns
+// the function that ends it, records the query latency and logs failures
+// with the shard and bucket. pgx.ErrNoRows is an expected outcome, not a
+// failed query.
+func (r *PostgresTransactionRepository) startQuery(ctx context.Context, bucket *database.BucketInfo, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "postgres."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.namespace", bucket.Schema()),
+			attribute.String("fintrack.shard", bucket.ShardName),
+		),
+	)
+
+	ctx = logging.With(ctx, "shard", bucket.ShardName, "bucket", bucket.Schema())
+
+	return ctx, func(err error) {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = nil
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logging.FromContext(ctx).Error("Query failed", "operation", operation, "error", err)
+		}
+		span.End()
+		r.metrics.ObserveDBQuery(bucket.ShardName, operation, start, err)
+	}
+}
+
+func (r *PostgresTransactionRepository) CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, int64, error) {
+	bucket := r.bucketManager.GetBucketForUser(tx.UserID)
+	pool, err := bucket.WritePool()
+	if err != nil {
+		return domain.Transaction{}, 0, err
+	}
+
+	id, err := r.ids.Next(bucket.ShardIndex, bucket.BucketIndex)
+	if err != nil {
+		return domain.Transaction{}, 0, fmt.Errorf("generate transaction id: %w", err)
+	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO %s.transactions (user_id, amount, category, type)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO %s.transactions (id, user_id, amount, category, type)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at;
-	`, schema)
+	`, bucket.Schema())
 
-	row := pool.QueryRow(ctx, query, tx.UserID, tx.Amount, tx.Category, tx.Type)
-	if err := row.Scan(&tx.ID, &tx.CreatedAt); err != nil {
-		return domain.Transaction{}, fmt.Errorf("insert transaction: %w", err)
+	var version int64
+	ctx, done := r.startQuery(ctx, bucket, "create")
+	err = pgx.BeginFunc(ctx, pool, func(dbtx pgx.Tx) error {
+		if err := dbtx.QueryRow(ctx, query, id, tx.UserID, tx.Amount, tx.Category, tx.Type).Scan(&tx.ID, &tx.CreatedAt); err != nil {
+			return fmt.Errorf("insert transaction: %w", err)
+		}
+		version, err = bumpVersion(ctx, dbtx, bucket.Schema(), tx.UserID)
+		return err
+	})
+	done(err)
+	if err != nil {
+		return domain.Transaction{}, 0, err
 	}
+	r.bucketManager.MarkWrite(tx.UserID)
 
-	return tx, nil
+	return tx, version, nil
+}
+
+// bumpVersion counts a write to the user's transactions in the database
+// transaction of the write and returns the user's new version.
+func bumpVersion(ctx context.Context, tx pgx.Tx, schema string, userID int) (int64, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s.user_versions AS v (user_id, version)
+		VALUES ($1, 1)
+		ON CONFLICT (user_id) DO UPDATE SET version = v.version + 1
+		RETURNING version;
+	`, schema)
+
+	var version int64
+	if err := tx.QueryRow(ctx, query, userID).Scan(&version); err != nil {
+		return 0, fmt.Errorf("bump user version: %w", err)
+	}
+	return version, nil
 }
 
 func (r *PostgresTransactionRepository) ListUserTransactions(ctx context.Context, userID int) ([]domain.Transaction, error) {
-	pool := r.bucketManager.GetPoolForUser(userID)
+	pool, err := r.bucketManager.GetReadPoolForUser(userID)
+	if err != nil {
+		return nil, err
+	}
 	schema := r.bucketManager.GetBucketSchema(userID)
 
 	query := fmt.Sprintf(`
@@ -48,8 +132,10 @@ func (r *PostgresTransactionRepository) ListUserTransactions(ctx context.Context
 		ORDER BY created_at DESC
 	`, schema)
 
+	ctx, done := r.startQuery(ctx, r.bucketManager.GetBucketForUser(userID), "list")
 	rows, err := pool.Query(ctx, query, userID)
 	if err != nil {
+		done(err)
 		return nil, fmt.Errorf("query transactions: %w", err)
 	}
 	defer rows.Close()
@@ -58,57 +144,302 @@ func (r *PostgresTransactionRepository) ListUserTransactions(ctx context.Context
 	for rows.Next() {
 		var tx domain.Transaction
 		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.Amount, &tx.Category, &tx.Type, &tx.CreatedAt); err != nil {
+			done(err)
+			return nil, fmt.Errorf("scan transaction: %w", err)
+		}
+		result = append(result, tx)
+	}
+
+	err = rows.Err()
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *PostgresTransactionRepository) GetTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, error) {
+	pool, err := r.bucketManager.GetReadPoolForUser(userID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	schema := r.bucketManager.GetBucketSchema(userID)
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, amount, category, type, created_at
+		FROM %s.transactions
+		WHERE id = $1 AND user_id = $2
+	`, schema)
+
+	var tx domain.Transaction
+	ctx, done := r.startQuery(ctx, r.bucketManager.GetBucketForUser(userID), "get")
+	err = pool.QueryRow(ctx, query, transactionID, userID).Scan(&tx.ID, &tx.UserID, &tx.Amount, &tx.Category, &tx.Type, &tx.CreatedAt)
+	done(err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transaction{}, domain.ErrTransactionNotFound
+		}
+		return domain.Transaction{}, fmt.Errorf("get transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (r *PostgresTransactionRepository) QueryUserTransactions(ctx context.Context, userID int, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	pool, err := r.bucketManager.GetReadPoolForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	schema := r.bucketManager.GetBucketSchema(userID)
+
+	where, args := filterConditions(userID, filter, after)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, amount, category, type, created_at
+		FROM %s.transactions
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, schema, strings.Join(where, " AND "), len(args)+1)
+	args = append(args, limit)
+
+	ctx, done := r.startQuery(ctx, r.bucketManager.GetBucketForUser(userID), "query")
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		done(err)
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.Transaction, 0, limit)
+	for rows.Next() {
+		var tx domain.Transaction
+		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.Amount, &tx.Category, &tx.Type, &tx.CreatedAt); err != nil {
+			done(err)
 			return nil, fmt.Errorf("scan transaction: %w", err)
 		}
 		result = append(result, tx)
 	}
 
-	if err := rows.Err(); err != nil {
+	err = rows.Err()
+	done(err)
+	if err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
 	return result, nil
 }
 
-func (r *PostgresTransactionRepository) UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
-	pool := r.bucketManager.GetPoolForUser(tx.UserID)
+// errReplicaBehind stops a snapshot on a replica that has not replayed the
+// write of the requested version yet.
+var errReplicaBehind = errors.New("replica is behind the requested version")
+
+// StreamUserTransactions reads the user's version and transactions in one
+// read-only, repeatable read transaction on one pool, so every batch is
+// the same snapshot, taken on the same server, of exactly the writes up to
+// the version. The transactions come from a single query whose rows are
+// decoded as they arrive; only the current batch is held in memory, and
+// the transaction stays open until fn has taken the last batch.
+//
+// A replica older than minVersion is dropped before anything is sent and
+// the snapshot is taken on the primary.
+func (r *PostgresTransactionRepository) StreamUserTransactions(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error) error {
+	bucket := r.bucketManager.GetBucketForUser(userID)
+	pool, err := r.bucketManager.GetReadPoolForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	// Errors from fn are the caller's, not failed queries.
+	var fnErr error
+	send := func(batch domain.TransactionBatch) error {
+		fnErr = fn(batch)
+		return fnErr
+	}
+
+	ctx, done := r.startQuery(ctx, bucket, "stream")
+	err = r.streamSnapshot(ctx, pool, bucket.Schema(), userID, minVersion, batchSize, send)
+	if errors.Is(err, errReplicaBehind) {
+		if pool, err = bucket.WritePool(); err == nil {
+			err = r.streamSnapshot(ctx, pool, bucket.Schema(), userID, 0, batchSize, send)
+		}
+	}
+	if fnErr != nil {
+		done(nil)
+		return fnErr
+	}
+	done(err)
+	return err
+}
+
+func (r *PostgresTransactionRepository) streamSnapshot(ctx context.Context, pool *pgxpool.Pool, schema string, userID int, minVersion int64, batchSize int, send func(domain.TransactionBatch) error) error {
+	versionQuery := fmt.Sprintf(`
+		SELECT COALESCE((SELECT version FROM %s.user_versions WHERE user_id = $1), 0)
+	`, schema)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, amount, category, type, created_at
+		FROM %s.transactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, schema)
+
+	options := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	return pgx.BeginTxFunc(ctx, pool, options, func(tx pgx.Tx) error {
+		var version int64
+		if err := tx.QueryRow(ctx, versionQuery, userID).Scan(&version); err != nil {
+			return fmt.Errorf("query user version: %w", err)
+		}
+		if version < minVersion {
+			return errReplicaBehind
+		}
+
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return fmt.Errorf("query transactions: %w", err)
+		}
+		defer rows.Close()
+
+		sent := false
+		batch := domain.TransactionBatch{Version: version, Transactions: make([]domain.Transaction, 0, batchSize)}
+		for rows.Next() {
+			var t domain.Transaction
+			if err := rows.Scan(&t.ID, &t.UserID, &t.Amount, &t.Category, &t.Type, &t.CreatedAt); err != nil {
+				return fmt.Errorf("scan transaction: %w", err)
+			}
+			batch.Transactions = append(batch.Transactions, t)
+			if len(batch.Transactions) == batchSize {
+				if err := send(batch); err != nil {
+					return err
+				}
+				sent = true
+				batch.Transactions = make([]domain.Transaction, 0, batchSize)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("rows error: %w", err)
+		}
+		// A user without transactions still gets the version.
+		if len(batch.Transactions) > 0 || !sent {
+			return send(batch)
+		}
+		return nil
+	})
+}
+
+// filterConditions builds the WHERE clause of QueryUserTransactions. The
+// cursor condition is a row comparison so that (created_at, id) can use
+// the same index order as the ORDER BY.
+func filterConditions(userID int, filter domain.TransactionFilter, after *domain.TransactionCursor) ([]string, []any) {
+	where := []string{"user_id = $1"}
+	args := []any{userID}
+	add := func(condition string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		where = append(where, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.Type != "" {
+		add("type = $%d", filter.Type)
+	}
+	if filter.Category != "" {
+		add("category = $%d", filter.Category)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("created_at < $%d", filter.To)
+	}
+	if after != nil {
+		add("(created_at, id) < ($%d, $%d)", after.CreatedAt, after.ID)
+	}
+	return where, args
+}
+
+// UpdateTransaction reads the previous values in the statement that
+// replaces them; the row lock keeps a concurrent update from slipping in
+// between.
+func (r *PostgresTransactionRepository) UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, domain.Transaction, int64, error) {
+	pool, err := r.bucketManager.GetWritePoolForUser(tx.UserID)
+	if err != nil {
+		return domain.Transaction{}, domain.Transaction{}, 0, err
+	}
 	schema := r.bucketManager.GetBucketSchema(tx.UserID)
 
 	query := fmt.Sprintf(`
-		UPDATE %s.transactions
+		UPDATE %[1]s.transactions AS t
 		SET amount = $1,
 		    category = $2,
 		    type = $3
-		WHERE id = $4 AND user_id = $5
-		RETURNING created_at;
+		FROM (
+			SELECT id, amount, category, type
+			FROM %[1]s.transactions
+			WHERE id = $4 AND user_id = $5
+			FOR UPDATE
+		) AS old
+		WHERE t.id = old.id
+		RETURNING t.created_at, old.amount, old.category, old.type;
 	`, schema)
 
-	row := pool.QueryRow(ctx, query, tx.Amount, tx.Category, tx.Type, tx.ID, tx.UserID)
-	if err := row.Scan(&tx.CreatedAt); err != nil {
+	previous := domain.Transaction{ID: tx.ID, UserID: tx.UserID}
+	var version int64
+	ctx, done := r.startQuery(ctx, r.bucketManager.GetBucketForUser(tx.UserID), "update")
+	err = pgx.BeginFunc(ctx, pool, func(dbtx pgx.Tx) error {
+		err := dbtx.QueryRow(ctx, query, tx.Amount, tx.Category, tx.Type, tx.ID, tx.UserID).
+			Scan(&tx.CreatedAt, &previous.Amount, &previous.Category, &previous.Type)
+		if err != nil {
+			return err
+		}
+		version, err = bumpVersion(ctx, dbtx, schema, tx.UserID)
+		return err
+	})
+	done(err)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.Transaction{}, domain.ErrTransactionNotFound
+			return domain.Transaction{}, domain.Transaction{}, 0, domain.ErrTransactionNotFound
 		}
-		return domain.Transaction{}, fmt.Errorf("update transaction: %w", err)
+		return domain.Transaction{}, domain.Transaction{}, 0, fmt.Errorf("update transaction: %w", err)
 	}
+	r.bucketManager.MarkWrite(tx.UserID)
+	previous.CreatedAt = tx.CreatedAt
 
-	return tx, nil
+	return tx, previous, version, nil
 }
 
-func (r *PostgresTransactionRepository) DeleteTransaction(ctx context.Context, userID int, transactionID int64) error {
-	pool := r.bucketManager.GetPoolForUser(userID)
+func (r *PostgresTransactionRepository) DeleteTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, int64, error) {
+	pool, err := r.bucketManager.GetWritePoolForUser(userID)
+	if err != nil {
+		return domain.Transaction{}, 0, err
+	}
 	schema := r.bucketManager.GetBucketSchema(userID)
 
 	query := fmt.Sprintf(`
 		DELETE FROM %s.transactions
-		WHERE id = $1 AND user_id = $2;
+		WHERE id = $1 AND user_id = $2
+		RETURNING amount, category, type, created_at;
 	`, schema)
 
-	commandTag, err := pool.Exec(ctx, query, transactionID, userID)
+	deleted := domain.Transaction{ID: transactionID, UserID: userID}
+	var version int64
+	ctx, done := r.startQuery(ctx, r.bucketManager.GetBucketForUser(userID), "delete")
+	err = pgx.BeginFunc(ctx, pool, func(dbtx pgx.Tx) error {
+		err := dbtx.QueryRow(ctx, query, transactionID, userID).Scan(&deleted.Amount, &deleted.Category, &deleted.Type, &deleted.CreatedAt)
+		if err != nil {
+			return err
+		}
+		version, err = bumpVersion(ctx, dbtx, schema, userID)
+		return err
+	})
+	done(err)
 	if err != nil {
-		return fmt.Errorf("delete transaction: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return domain.ErrTransactionNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transaction{}, 0, domain.ErrTransactionNotFound
+		}
+		return domain.Transaction{}, 0, fmt.Errorf("delete transaction: %w", err)
 	}
-	return nil
+	r.bucketManager.MarkWrite(userID)
+	return deleted, version, nil
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fin-api/internal/domain"
+)
+
+func TestFilterConditions(t *testing.T) {
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &domain.TransactionCursor{CreatedAt: from.Add(time.Hour), ID: 42}
+
+	where, args := filterConditions(7, domain.TransactionFilter{
+		Type:     domain.TransactionTypeExpense,
+		Category: "food",
+		From:     from,
+	}, cursor)
+
+	assert.Equal(t, []string{
+		"user_id = $1",
+		"type = $2",
+		"category = $3",
+		"created_at >= $4",
+		"(created_at, id) < ($5, $6)",
+	}, where)
+	assert.Equal(t, []any{7, domain.TransactionTypeExpense, "food", from, cursor.CreatedAt, int64(42)}, args)
+
+	where, args = filterConditions(7, domain.TransactionFilter{}, nil)
+	assert.Equal(t, []string{"user_id = $1"}, where)
+	assert.Equal(t, []any{7}, args)
+}
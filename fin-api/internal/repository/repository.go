@@ -6,9 +6,26 @@ import (
 	"fin-api/internal/domain"
 )
 
+// TransactionRepository stores the transactions of users. Every write
+// bumps the user's version in the same database transaction and returns
+// it: versions count a user's writes in the order they committed.
 type TransactionRepository interface {
-	CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)
+	CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, int64, error)
+	GetTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, error)
 	ListUserTransactions(ctx context.Context, userID int) ([]domain.Transaction, error)
-	UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)
-	DeleteTransaction(ctx context.Context, userID int, transactionID int64) error
+	// QueryUserTransactions returns up to limit transactions matching filter,
+	// newest first, starting after the cursor when it is not nil.
+	QueryUserTransactions(ctx context.Context, userID int, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error)
+	// StreamUserTransactions passes all of the user's transactions to fn in
+	// batches of up to batchSize, newest first, and stops at the first
+	// error fn returns. Every batch carries the user's version the
+	// transactions are a snapshot of; a user without transactions gets
+	// one empty batch. The snapshot is taken on the primary if a replica
+	// has not replayed the write of minVersion yet.
+	StreamUserTransactions(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error) error
+	// UpdateTransaction also returns the transaction as it was before the
+	// update.
+	UpdateTransaction(ctx context.Context, tx domain.Transaction) (updated, previous domain.Transaction, version int64, err error)
+	// DeleteTransaction returns the deleted transaction.
+	DeleteTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, int64, error)
 }
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fin-api/internal/domain"
+	repo "fin-api/internal/repository"
+)
+
+const (
+	defaultStatsPeriod  = 30 * 24 * time.Hour
+	defaultActiveLimit  = 100
+	maxActiveUsersLimit = 1000
+)
+
+type AdminService struct {
+	repo repo.AdminRepository
+	now  func() time.Time
+}
+
+func NewAdminService(repo repo.AdminRepository) *AdminService {
+	return &AdminService{repo: repo, now: time.Now}
+}
+
+// GlobalStats returns platform totals for [from, to). Zero bounds default to
+// the last 30 days.
+func (s *AdminService) GlobalStats(ctx context.Context, from, to time.Time) (domain.GlobalStats, error) {
+	if to.IsZero() {
+		to = s.now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultStatsPeriod)
+	}
+	if !from.Before(to) {
+		return domain.GlobalStats{}, fmt.Errorf("%w: from must be before to", domain.ErrInvalidArgument)
+	}
+
+	return s.repo.GlobalStats(ctx, from, to)
+}
+
+func (s *AdminService) ActiveUsers(ctx context.Context, since time.Time, limit int) (domain.ActiveUsers, error) {
+	if since.IsZero() {
+		return domain.ActiveUsers{}, fmt.Errorf("%w: active_since is required", domain.ErrInvalidArgument)
+	}
+	if limit <= 0 {
+		limit = defaultActiveLimit
+	}
+	if limit > maxActiveUsersLimit {
+		limit = maxActiveUsersLimit
+	}
+
+	return s.repo.ActiveUsers(ctx, since, limit)
+}
@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	repomocks "fin-api/internal/repository/mocks"
+	"fin-api/internal/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"fin-api/internal/domain"
+)
+
+type AdminServiceTestSuite struct {
+	suite.Suite
+	mockRepo *repomocks.AdminRepository
+	service  *service.AdminService
+}
+
+func (s *AdminServiceTestSuite) SetupTest() {
+	s.mockRepo = repomocks.NewAdminRepository(s.T())
+	s.service = service.NewAdminService(s.mockRepo)
+}
+
+func (s *AdminServiceTestSuite) TestGlobalStatsDefaultsToLast30Days() {
+	ctx := context.Background()
+
+	s.mockRepo.On("GlobalStats", ctx, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, from, to time.Time) (domain.GlobalStats, error) {
+			return domain.GlobalStats{From: from, To: to, TransactionsCount: 3}, nil
+		})
+
+	stats, err := s.service.GlobalStats(ctx, time.Time{}, time.Time{})
+	s.NoError(err)
+	s.Equal(3, stats.TransactionsCount)
+	s.Equal(30*24*time.Hour, stats.To.Sub(stats.From))
+	s.WithinDuration(time.Now(), stats.To, time.Minute)
+}
+
+func (s *AdminServiceTestSuite) TestGlobalStatsRejectsEmptyRange() {
+	day := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := s.service.GlobalStats(context.Background(), day, day)
+	s.ErrorIs(err, domain.ErrInvalidArgument)
+}
+
+func (s *AdminServiceTestSuite) TestActiveUsersRequiresSince() {
+	_, err := s.service.ActiveUsers(context.Background(), time.Time{}, 10)
+	s.ErrorIs(err, domain.ErrInvalidArgument)
+}
+
+func (s *AdminServiceTestSuite) TestActiveUsersClampsLimit() {
+	ctx := context.Background()
+	since := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	users := domain.ActiveUsers{Since: since, Total: 1, Users: []domain.ActiveUser{{UserID: 7}}}
+
+	s.mockRepo.On("ActiveUsers", ctx, since, 100).Return(users, nil).Once()
+	s.mockRepo.On("ActiveUsers", ctx, since, 1000).Return(users, nil).Once()
+
+	result, err := s.service.ActiveUsers(ctx, since, 0)
+	s.NoError(err)
+	s.Equal(users, result)
+
+	_, err = s.service.ActiveUsers(ctx, since, 50000)
+	s.NoError(err)
+}
+
+func TestAdminServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminServiceTestSuite))
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-api/internal/domain"
+)
+
+// Page tokens are opaque to clients; they encode the cursor of the last
+// transaction of the previous page.
+
+func encodePageToken(cursor domain.TransactionCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "." + strconv.FormatInt(cursor.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*domain.TransactionCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	invalid := fmt.Errorf("%w: invalid page token", domain.ErrInvalidArgument)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	nanos, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, invalid
+	}
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	transactionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	return &domain.TransactionCursor{CreatedAt: time.Unix(0, createdAt).UTC(), ID: transactionID}, nil
+}
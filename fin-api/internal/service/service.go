@@ -4,10 +4,15 @@ import (
 	"context"
 	repo "fin-api/internal/repository"
 	"fmt"
-	"log"
 
 	"fin-api/internal/domain"
 	publisher "fin-api/internal/kafka"
+	"fin-shared/logging"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
 )
 
 type TransactionService struct {
@@ -23,13 +28,14 @@ func NewTransactionService(repo repo.TransactionRepository, publisher publisher.
 }
 
 func (s *TransactionService) CreateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
-	created, err := s.repo.CreateTransaction(ctx, tx)
+	created, version, err := s.repo.CreateTransaction(ctx, tx)
 	if err != nil {
 		return domain.Transaction{}, err
 	}
 
-	if err := s.publishUserTransactions(ctx, tx.UserID); err != nil {
-		log.Printf("Failed to publish to Kafka (userID: %d): %v", tx.UserID, err)
+	change := &domain.TransactionChange{Op: domain.ChangeCreate, Transaction: created}
+	if err := s.publishChange(ctx, tx.UserID, version, change); err != nil {
+		logging.FromContext(ctx).Error("Failed to publish to Kafka", "error", err)
 	}
 
 	return created, nil
@@ -39,36 +45,83 @@ func (s *TransactionService) ListTransactions(ctx context.Context, userID int) (
 	return s.repo.ListUserTransactions(ctx, userID)
 }
 
+func (s *TransactionService) GetTransaction(ctx context.Context, userID int, transactionID int64) (domain.Transaction, error) {
+	return s.repo.GetTransaction(ctx, userID, transactionID)
+}
+
+// ListTransactionsPage returns one page of the user's transactions matching
+// filter, newest first. pageSize defaults to 50 and is capped at 500.
+func (s *TransactionService) ListTransactionsPage(ctx context.Context, userID int, filter domain.TransactionFilter, pageSize int, pageToken string) (domain.TransactionPage, error) {
+	if filter.Type != "" && !filter.Type.Valid() {
+		return domain.TransactionPage{}, fmt.Errorf("%w: type must be income or expense", domain.ErrInvalidArgument)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return domain.TransactionPage{}, fmt.Errorf("%w: from must be before to", domain.ErrInvalidArgument)
+	}
+	if pageSize < 0 {
+		return domain.TransactionPage{}, fmt.Errorf("%w: page_size must not be negative", domain.ErrInvalidArgument)
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return domain.TransactionPage{}, err
+	}
+
+	// One extra row tells whether another page follows.
+	items, err := s.repo.QueryUserTransactions(ctx, userID, filter, after, pageSize+1)
+	if err != nil {
+		return domain.TransactionPage{}, err
+	}
+
+	page := domain.TransactionPage{Transactions: items}
+	if len(items) > pageSize {
+		page.Transactions = items[:pageSize]
+		last := page.Transactions[pageSize-1]
+		page.NextPageToken = encodePageToken(domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+// StreamTransactions passes all of the user's transactions to fn in
+// batches of batchSize, newest first. The batches are one consistent
+// snapshot at the version they carry, at least minVersion if the user has
+// reached it, and neither side holds the whole history.
+func (s *TransactionService) StreamTransactions(ctx context.Context, userID int, minVersion int64, batchSize int, fn func(domain.TransactionBatch) error) error {
+	return s.repo.StreamUserTransactions(ctx, userID, minVersion, batchSize, fn)
+}
+
 func (s *TransactionService) UpdateTransaction(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
-	updated, err := s.repo.UpdateTransaction(ctx, tx)
+	updated, previous, version, err := s.repo.UpdateTransaction(ctx, tx)
 	if err != nil {
 		return domain.Transaction{}, err
 	}
 
-	if err := s.publishUserTransactions(ctx, tx.UserID); err != nil {
-		log.Printf("Failed to publish to Kafka (userID: %d): %v", tx.UserID, err)
+	change := &domain.TransactionChange{Op: domain.ChangeUpdate, Transaction: updated, Previous: &previous}
+	if err := s.publishChange(ctx, tx.UserID, version, change); err != nil {
+		logging.FromContext(ctx).Error("Failed to publish to Kafka", "error", err)
 	}
 
 	return updated, nil
 }
 
 func (s *TransactionService) DeleteTransaction(ctx context.Context, userID int, transactionID int64) error {
-	if err := s.repo.DeleteTransaction(ctx, userID, transactionID); err != nil {
+	deleted, version, err := s.repo.DeleteTransaction(ctx, userID, transactionID)
+	if err != nil {
 		return err
 	}
 
-	return s.publishUserTransactions(ctx, userID)
+	return s.publishChange(ctx, userID, version, &domain.TransactionChange{Op: domain.ChangeDelete, Transaction: deleted})
 }
 
-func (s *TransactionService) publishUserTransactions(ctx context.Context, userID int) error {
-	all, err := s.repo.ListUserTransactions(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("list user transactions: %w", err)
-	}
-
+func (s *TransactionService) publishChange(ctx context.Context, userID int, version int64, change *domain.TransactionChange) error {
 	if err := s.publisher.PublishTransactions(ctx, domain.TransactionMessage{
-		UserID:       userID,
-		Transactions: all,
+		UserID:  userID,
+		Version: version,
+		Change:  change,
 	}); err != nil {
 		return fmt.Errorf("publish event: %w", err)
 	}
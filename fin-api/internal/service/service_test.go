@@ -6,6 +6,7 @@ import (
 	repomocks "fin-api/internal/repository/mocks"
 	"fin-api/internal/service"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -39,10 +40,9 @@ func (s *TransactionServiceTestSuite) TestCreateTransactionSuccess() {
 	createdTx := tx
 	createdTx.ID = 1
 
-	s.mockRepo.On("CreateTransaction", ctx, tx).Return(createdTx, nil)
-	s.mockRepo.On("ListUserTransactions", ctx, userID).Return([]domain.Transaction{createdTx}, nil)
+	s.mockRepo.On("CreateTransaction", ctx, tx).Return(createdTx, int64(4), nil)
 	s.mockPublisher.On("PublishTransactions", ctx, mock.MatchedBy(func(msg domain.TransactionMessage) bool {
-		return msg.UserID == userID && len(msg.Transactions) == 1 && msg.Transactions[0].ID == 1
+		return msg.UserID == userID && msg.Version == 4 && msg.Change.Op == domain.ChangeCreate && msg.Change.Transaction == createdTx
 	})).Return(nil)
 
 	result, err := s.service.CreateTransaction(ctx, tx)
@@ -54,7 +54,7 @@ func (s *TransactionServiceTestSuite) TestCreateTransactionRepoError() {
 	ctx := context.Background()
 	tx := domain.Transaction{UserID: 1}
 
-	s.mockRepo.On("CreateTransaction", ctx, tx).Return(domain.Transaction{}, errors.New("repo error"))
+	s.mockRepo.On("CreateTransaction", ctx, tx).Return(domain.Transaction{}, int64(0), errors.New("repo error"))
 
 	_, err := s.service.CreateTransaction(ctx, tx)
 	s.Error(err)
@@ -78,10 +78,13 @@ func (s *TransactionServiceTestSuite) TestUpdateTransactionSuccess() {
 	userID := 1
 	tx := domain.Transaction{ID: 1, UserID: userID, Amount: 150}
 	updatedTx := tx
+	previous := domain.Transaction{ID: 1, UserID: userID, Amount: 100}
 
-	s.mockRepo.On("UpdateTransaction", ctx, tx).Return(updatedTx, nil)
-	s.mockRepo.On("ListUserTransactions", ctx, userID).Return([]domain.Transaction{updatedTx}, nil)
-	s.mockPublisher.On("PublishTransactions", ctx, mock.Anything).Return(nil)
+	s.mockRepo.On("UpdateTransaction", ctx, tx).Return(updatedTx, previous, int64(5), nil)
+	s.mockPublisher.On("PublishTransactions", ctx, mock.MatchedBy(func(msg domain.TransactionMessage) bool {
+		return msg.Version == 5 && msg.Change != nil && msg.Change.Op == domain.ChangeUpdate &&
+			msg.Change.Transaction == updatedTx && *msg.Change.Previous == previous
+	})).Return(nil)
 
 	result, err := s.service.UpdateTransaction(ctx, tx)
 	s.NoError(err)
@@ -93,7 +96,7 @@ func (s *TransactionServiceTestSuite) TestUpdateTransactionRepoError() {
 	userID := 1
 	tx := domain.Transaction{ID: 999, UserID: userID, Amount: 150}
 
-	s.mockRepo.On("UpdateTransaction", ctx, tx).Return(domain.Transaction{}, errors.New("transaction not found"))
+	s.mockRepo.On("UpdateTransaction", ctx, tx).Return(domain.Transaction{}, domain.Transaction{}, int64(0), errors.New("transaction not found"))
 
 	_, err := s.service.UpdateTransaction(ctx, tx)
 	s.Error(err)
@@ -105,9 +108,12 @@ func (s *TransactionServiceTestSuite) TestDeleteTransactionSuccess() {
 	userID := 1
 	txID := int64(1)
 
-	s.mockRepo.On("DeleteTransaction", ctx, userID, txID).Return(nil)
-	s.mockRepo.On("ListUserTransactions", ctx, userID).Return([]domain.Transaction{}, nil)
-	s.mockPublisher.On("PublishTransactions", ctx, mock.Anything).Return(nil)
+	deleted := domain.Transaction{ID: txID, UserID: userID, Amount: 20}
+
+	s.mockRepo.On("DeleteTransaction", ctx, userID, txID).Return(deleted, int64(6), nil)
+	s.mockPublisher.On("PublishTransactions", ctx, mock.MatchedBy(func(msg domain.TransactionMessage) bool {
+		return msg.Change != nil && msg.Change.Op == domain.ChangeDelete && msg.Change.Transaction == deleted && msg.Version == 6
+	})).Return(nil)
 
 	err := s.service.DeleteTransaction(ctx, userID, txID)
 	s.NoError(err)
@@ -118,13 +124,101 @@ func (s *TransactionServiceTestSuite) TestDeleteTransactionRepoError() {
 	userID := 1
 	txID := int64(999)
 
-	s.mockRepo.On("DeleteTransaction", ctx, userID, txID).Return(errors.New("transaction not found"))
+	s.mockRepo.On("DeleteTransaction", ctx, userID, txID).Return(domain.Transaction{}, int64(0), errors.New("transaction not found"))
 
 	err := s.service.DeleteTransaction(ctx, userID, txID)
 	s.Error(err)
 	s.Equal("transaction not found", err.Error())
 }
 
+func (s *TransactionServiceTestSuite) TestListTransactionsPageFollowsToken() {
+	ctx := context.Background()
+	userID := 1
+	filter := domain.TransactionFilter{Type: domain.TransactionTypeExpense}
+	newest := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	txs := []domain.Transaction{
+		{ID: 3, UserID: userID, CreatedAt: newest},
+		{ID: 2, UserID: userID, CreatedAt: newest.Add(-time.Hour)},
+		{ID: 1, UserID: userID, CreatedAt: newest.Add(-2 * time.Hour)},
+	}
+
+	s.mockRepo.On("QueryUserTransactions", ctx, userID, filter, (*domain.TransactionCursor)(nil), 3).Return(txs, nil).Once()
+	page, err := s.service.ListTransactionsPage(ctx, userID, filter, 2, "")
+	s.Require().NoError(err)
+	s.Equal(txs[:2], page.Transactions)
+	s.NotEmpty(page.NextPageToken)
+
+	cursor := &domain.TransactionCursor{CreatedAt: txs[1].CreatedAt, ID: 2}
+	s.mockRepo.On("QueryUserTransactions", ctx, userID, filter, cursor, 3).Return(txs[2:], nil).Once()
+	page, err = s.service.ListTransactionsPage(ctx, userID, filter, 2, page.NextPageToken)
+	s.Require().NoError(err)
+	s.Equal(txs[2:], page.Transactions)
+	s.Empty(page.NextPageToken)
+}
+
+func (s *TransactionServiceTestSuite) TestListTransactionsPageClampsPageSize() {
+	ctx := context.Background()
+
+	s.mockRepo.On("QueryUserTransactions", ctx, 1, domain.TransactionFilter{}, (*domain.TransactionCursor)(nil), 51).Return(nil, nil).Once()
+	s.mockRepo.On("QueryUserTransactions", ctx, 1, domain.TransactionFilter{}, (*domain.TransactionCursor)(nil), 501).Return(nil, nil).Once()
+
+	_, err := s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{}, 0, "")
+	s.NoError(err)
+	_, err = s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{}, 10000, "")
+	s.NoError(err)
+}
+
+func (s *TransactionServiceTestSuite) TestListTransactionsPageRejectsInvalidArguments() {
+	ctx := context.Background()
+	day := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, call := range map[string]func() error{
+		"type": func() error {
+			_, err := s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{Type: "refund"}, 0, "")
+			return err
+		},
+		"range": func() error {
+			_, err := s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{From: day, To: day}, 0, "")
+			return err
+		},
+		"page size": func() error {
+			_, err := s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{}, -1, "")
+			return err
+		},
+		"token": func() error {
+			_, err := s.service.ListTransactionsPage(ctx, 1, domain.TransactionFilter{}, 0, "not a token")
+			return err
+		},
+	} {
+		s.ErrorIs(call(), domain.ErrInvalidArgument, name)
+	}
+}
+
+func (s *TransactionServiceTestSuite) TestStreamTransactionsPassesRepositoryBatches() {
+	ctx := context.Background()
+	batches := []domain.TransactionBatch{
+		{Version: 7, Transactions: []domain.Transaction{{ID: 3}, {ID: 2}}},
+		{Version: 7, Transactions: []domain.Transaction{{ID: 1}}},
+	}
+
+	s.mockRepo.On("StreamUserTransactions", ctx, 1, int64(6), 2, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(4).(func(domain.TransactionBatch) error)
+			for _, batch := range batches {
+				s.Require().NoError(fn(batch))
+			}
+		}).
+		Return(nil).Once()
+
+	var streamed []domain.TransactionBatch
+	err := s.service.StreamTransactions(ctx, 1, 6, 2, func(batch domain.TransactionBatch) error {
+		streamed = append(streamed, batch)
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(batches, streamed)
+}
+
 func TestTransactionServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionServiceTestSuite))
 }
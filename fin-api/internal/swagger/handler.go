@@ -50,7 +50,7 @@ func UIHandler(specURL string) http.HandlerFunc {
 
 func SpecHandler(content []byte) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write(content)
 	}
 }
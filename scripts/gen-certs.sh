@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+# Generates a local CA and certificates for fin-api and fin-analytics in
+# ./certs (or $1) for trying TLS and mutual TLS without a real PKI.
+#
+# The CA is kept between runs, so running the script again reissues only
+# the service certificates: the running services pick them up without a
+# restart. Delete the directory to start over with a new CA.
+set -euo pipefail
+
+dir="${1:-certs}"
+days=30
+mkdir -p "$dir"
+cd "$dir"
+
+if [[ ! -f ca.key ]]; then
+	openssl ecparam -name prime256v1 -genkey -noout -out ca.key
+	openssl req -x509 -new -key ca.key -sha256 -days 365 \
+		-subj "/CN=FinTrack dev CA" -out ca.crt
+	echo "created CA in $dir/ca.crt"
+fi
+
+issue() {
+	local name="$1"
+	openssl ecparam -name prime256v1 -genkey -noout -out "$name.key.tmp"
+	openssl req -new -key "$name.key.tmp" -subj "/CN=$name" -out "$name.csr"
+	# Both usages: fin-analytics presents its certificate as a client to
+	# fin-api and as a server to its own callers.
+	openssl x509 -req -in "$name.csr" -CA ca.crt -CAkey ca.key -CAcreateserial \
+		-sha256 -days "$days" -out "$name.crt.tmp" -extfile <(printf '%s\n' \
+		"subjectAltName=DNS:$name,DNS:localhost,IP:127.0.0.1" \
+		"keyUsage=digitalSignature" \
+		"extendedKeyUsage=serverAuth,clientAuth")
+	# Rename into place so a watching service never reads a half-written
+	# file; its reload debounce covers the gap between the two renames.
+	mv "$name.key.tmp" "$name.key"
+	mv "$name.crt.tmp" "$name.crt"
+	rm -f "$name.csr"
+	echo "issued $dir/$name.crt"
+}
+
+issue fin-api
+issue fin-analytics
+chmod 600 ./*.key
@@ -0,0 +1,526 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: fintrack/v1/admin.proto
+
+package fintrackv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GlobalStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the period, a date or RFC3339. Defaults to 30 days before to.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// End of the period, exclusive, a date or RFC3339. Defaults to now.
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalStatsRequest) Reset() {
+	*x = GlobalStatsRequest{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalStatsRequest) ProtoMessage() {}
+
+func (x *GlobalStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalStatsRequest.ProtoReflect.Descriptor instead.
+func (*GlobalStatsRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GlobalStatsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GlobalStatsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type DailyVolume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC3339, midnight UTC.
+	Day               string  `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	TotalIncome       float64 `protobuf:"fixed64,2,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpense      float64 `protobuf:"fixed64,3,opt,name=total_expense,json=totalExpense,proto3" json:"total_expense,omitempty"`
+	TransactionsCount int64   `protobuf:"varint,4,opt,name=transactions_count,json=transactionsCount,proto3" json:"transactions_count,omitempty"`
+	ActiveUsers       int64   `protobuf:"varint,5,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DailyVolume) Reset() {
+	*x = DailyVolume{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyVolume) ProtoMessage() {}
+
+func (x *DailyVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyVolume.ProtoReflect.Descriptor instead.
+func (*DailyVolume) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DailyVolume) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *DailyVolume) GetTotalIncome() float64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *DailyVolume) GetTotalExpense() float64 {
+	if x != nil {
+		return x.TotalExpense
+	}
+	return 0
+}
+
+func (x *DailyVolume) GetTransactionsCount() int64 {
+	if x != nil {
+		return x.TransactionsCount
+	}
+	return 0
+}
+
+func (x *DailyVolume) GetActiveUsers() int64 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+type GlobalStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	From              string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TotalIncome       float64                `protobuf:"fixed64,3,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpense      float64                `protobuf:"fixed64,4,opt,name=total_expense,json=totalExpense,proto3" json:"total_expense,omitempty"`
+	TransactionsCount int64                  `protobuf:"varint,5,opt,name=transactions_count,json=transactionsCount,proto3" json:"transactions_count,omitempty"`
+	ActiveUsers       int64                  `protobuf:"varint,6,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	Days              []*DailyVolume         `protobuf:"bytes,7,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GlobalStats) Reset() {
+	*x = GlobalStats{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalStats) ProtoMessage() {}
+
+func (x *GlobalStats) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalStats.ProtoReflect.Descriptor instead.
+func (*GlobalStats) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GlobalStats) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GlobalStats) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GlobalStats) GetTotalIncome() float64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *GlobalStats) GetTotalExpense() float64 {
+	if x != nil {
+		return x.TotalExpense
+	}
+	return 0
+}
+
+func (x *GlobalStats) GetTransactionsCount() int64 {
+	if x != nil {
+		return x.TransactionsCount
+	}
+	return 0
+}
+
+func (x *GlobalStats) GetActiveUsers() int64 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+func (x *GlobalStats) GetDays() []*DailyVolume {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type ActiveUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required, a date or RFC3339.
+	ActiveSince string `protobuf:"bytes,1,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`
+	// Defaults to 100, at most 1000.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveUsersRequest) Reset() {
+	*x = ActiveUsersRequest{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveUsersRequest) ProtoMessage() {}
+
+func (x *ActiveUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUsersRequest.ProtoReflect.Descriptor instead.
+func (*ActiveUsersRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ActiveUsersRequest) GetActiveSince() string {
+	if x != nil {
+		return x.ActiveSince
+	}
+	return ""
+}
+
+func (x *ActiveUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ActiveUser struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LastActivityAt    string                 `protobuf:"bytes,2,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	TransactionsCount int64                  `protobuf:"varint,3,opt,name=transactions_count,json=transactionsCount,proto3" json:"transactions_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ActiveUser) Reset() {
+	*x = ActiveUser{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveUser) ProtoMessage() {}
+
+func (x *ActiveUser) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUser.ProtoReflect.Descriptor instead.
+func (*ActiveUser) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActiveUser) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ActiveUser) GetLastActivityAt() string {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return ""
+}
+
+func (x *ActiveUser) GetTransactionsCount() int64 {
+	if x != nil {
+		return x.TransactionsCount
+	}
+	return 0
+}
+
+type ActiveUsers struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Since string                 `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Total int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Most recently active first.
+	Users         []*ActiveUser `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveUsers) Reset() {
+	*x = ActiveUsers{}
+	mi := &file_fintrack_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUsers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveUsers) ProtoMessage() {}
+
+func (x *ActiveUsers) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUsers.ProtoReflect.Descriptor instead.
+func (*ActiveUsers) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ActiveUsers) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ActiveUsers) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ActiveUsers) GetUsers() []*ActiveUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+var File_fintrack_v1_admin_proto protoreflect.FileDescriptor
+
+const file_fintrack_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x17fintrack/v1/admin.proto\x12\vfintrack.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"8\n" +
+	"\x12GlobalStatsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xdb\x01\n" +
+	"\vDailyVolume\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12!\n" +
+	"\ftotal_income\x18\x02 \x01(\x01R\vtotalIncome\x12#\n" +
+	"\rtotal_expense\x18\x03 \x01(\x01R\ftotalExpense\x12>\n" +
+	"\x12transactions_count\x18\x04 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x11transactionsCount\x122\n" +
+	"\factive_users\x18\x05 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\vactiveUsers\"\x9b\x02\n" +
+	"\vGlobalStats\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12!\n" +
+	"\ftotal_income\x18\x03 \x01(\x01R\vtotalIncome\x12#\n" +
+	"\rtotal_expense\x18\x04 \x01(\x01R\ftotalExpense\x12>\n" +
+	"\x12transactions_count\x18\x05 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x11transactionsCount\x122\n" +
+	"\factive_users\x18\x06 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\vactiveUsers\x12,\n" +
+	"\x04days\x18\a \x03(\v2\x18.fintrack.v1.DailyVolumeR\x04days\"M\n" +
+	"\x12ActiveUsersRequest\x12!\n" +
+	"\factive_since\x18\x01 \x01(\tR\vactiveSince\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa0\x01\n" +
+	"\n" +
+	"ActiveUser\x12(\n" +
+	"\auser_id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x06userId\x12(\n" +
+	"\x10last_activity_at\x18\x02 \x01(\tR\x0elastActivityAt\x12>\n" +
+	"\x12transactions_count\x18\x03 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x11transactionsCount\"y\n" +
+	"\vActiveUsers\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\x12%\n" +
+	"\x05total\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x05total\x12-\n" +
+	"\x05users\x18\x03 \x03(\v2\x17.fintrack.v1.ActiveUserR\x05users2\x82\x02\n" +
+	"\fAdminService\x12{\n" +
+	"\x0eGetGlobalStats\x12\x1f.fintrack.v1.GlobalStatsRequest\x1a\x18.fintrack.v1.GlobalStats\".\x92A\x10b\x0e\n" +
+	"\f\n" +
+	"\bAdminKey\x12\x00\x82\xd3\xe4\x93\x02\x15\x12\x13/admin/stats/global\x12u\n" +
+	"\x0fListActiveUsers\x12\x1f.fintrack.v1.ActiveUsersRequest\x1a\x18.fintrack.v1.ActiveUsers\"'\x92A\x10b\x0e\n" +
+	"\f\n" +
+	"\bAdminKey\x12\x00\x82\xd3\xe4\x93\x02\x0e\x12\f/admin/usersBY\x92A/R-\n" +
+	"\adefault\x12\"\n" +
+	"\bAn error\x12\x16\n" +
+	"\x14\x1a\x12.fintrack.v1.ErrorZ%fin-shared/api/fintrack/v1;fintrackv1b\x06proto3"
+
+var (
+	file_fintrack_v1_admin_proto_rawDescOnce sync.Once
+	file_fintrack_v1_admin_proto_rawDescData []byte
+)
+
+func file_fintrack_v1_admin_proto_rawDescGZIP() []byte {
+	file_fintrack_v1_admin_proto_rawDescOnce.Do(func() {
+		file_fintrack_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fintrack_v1_admin_proto_rawDesc), len(file_fintrack_v1_admin_proto_rawDesc)))
+	})
+	return file_fintrack_v1_admin_proto_rawDescData
+}
+
+var file_fintrack_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_fintrack_v1_admin_proto_goTypes = []any{
+	(*GlobalStatsRequest)(nil), // 0: fintrack.v1.GlobalStatsRequest
+	(*DailyVolume)(nil),        // 1: fintrack.v1.DailyVolume
+	(*GlobalStats)(nil),        // 2: fintrack.v1.GlobalStats
+	(*ActiveUsersRequest)(nil), // 3: fintrack.v1.ActiveUsersRequest
+	(*ActiveUser)(nil),         // 4: fintrack.v1.ActiveUser
+	(*ActiveUsers)(nil),        // 5: fintrack.v1.ActiveUsers
+}
+var file_fintrack_v1_admin_proto_depIdxs = []int32{
+	1, // 0: fintrack.v1.GlobalStats.days:type_name -> fintrack.v1.DailyVolume
+	4, // 1: fintrack.v1.ActiveUsers.users:type_name -> fintrack.v1.ActiveUser
+	0, // 2: fintrack.v1.AdminService.GetGlobalStats:input_type -> fintrack.v1.GlobalStatsRequest
+	3, // 3: fintrack.v1.AdminService.ListActiveUsers:input_type -> fintrack.v1.ActiveUsersRequest
+	2, // 4: fintrack.v1.AdminService.GetGlobalStats:output_type -> fintrack.v1.GlobalStats
+	5, // 5: fintrack.v1.AdminService.ListActiveUsers:output_type -> fintrack.v1.ActiveUsers
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_fintrack_v1_admin_proto_init() }
+func file_fintrack_v1_admin_proto_init() {
+	if File_fintrack_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fintrack_v1_admin_proto_rawDesc), len(file_fintrack_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fintrack_v1_admin_proto_goTypes,
+		DependencyIndexes: file_fintrack_v1_admin_proto_depIdxs,
+		MessageInfos:      file_fintrack_v1_admin_proto_msgTypes,
+	}.Build()
+	File_fintrack_v1_admin_proto = out.File
+	file_fintrack_v1_admin_proto_goTypes = nil
+	file_fintrack_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,1260 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: fintrack/v1/fintrack.proto
+
+package fintrackv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Interval int32
+
+const (
+	Interval_INTERVAL_UNSPECIFIED Interval = 0
+	Interval_INTERVAL_DAY         Interval = 1
+	Interval_INTERVAL_WEEK        Interval = 2
+	Interval_INTERVAL_MONTH       Interval = 3
+)
+
+// Enum value maps for Interval.
+var (
+	Interval_name = map[int32]string{
+		0: "INTERVAL_UNSPECIFIED",
+		1: "INTERVAL_DAY",
+		2: "INTERVAL_WEEK",
+		3: "INTERVAL_MONTH",
+	}
+	Interval_value = map[string]int32{
+		"INTERVAL_UNSPECIFIED": 0,
+		"INTERVAL_DAY":         1,
+		"INTERVAL_WEEK":        2,
+		"INTERVAL_MONTH":       3,
+	}
+)
+
+func (x Interval) Enum() *Interval {
+	p := new(Interval)
+	*p = x
+	return p
+}
+
+func (x Interval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Interval) Descriptor() protoreflect.EnumDescriptor {
+	return file_fintrack_v1_fintrack_proto_enumTypes[0].Descriptor()
+}
+
+func (Interval) Type() protoreflect.EnumType {
+	return &file_fintrack_v1_fintrack_proto_enumTypes[0]
+}
+
+func (x Interval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Interval.Descriptor instead.
+func (Interval) EnumDescriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{0}
+}
+
+// Error is the body of REST error responses.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRequest) Reset() {
+	*x = UserRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRequest) ProtoMessage() {}
+
+func (x *UserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRequest.ProtoReflect.Descriptor instead.
+func (*UserRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Transaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Transaction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type UserTransactions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserTransactions) Reset() {
+	*x = UserTransactions{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserTransactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserTransactions) ProtoMessage() {}
+
+func (x *UserTransactions) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserTransactions.ProtoReflect.Descriptor instead.
+func (*UserTransactions) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserTransactions) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type CreateTransactionRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount   float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	// income or expense.
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTransactionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type UpdateTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionRequest) Reset() {
+	*x = UpdateTransactionRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionRequest) ProtoMessage() {}
+
+func (x *UpdateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTransactionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type TransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransactionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionResponse) Reset() {
+	*x = DeleteTransactionResponse{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionResponse) ProtoMessage() {}
+
+func (x *DeleteTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{7}
+}
+
+type ListTransactionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Optional filters; empty fields match every transaction. from and to are
+	// RFC3339, from inclusive and to exclusive.
+	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Category string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	From     string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To       string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	// Defaults to 50, at most 500.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page; empty for the first page.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransactionsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type FinanceStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalIncome       float64                `protobuf:"fixed64,2,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpense      float64                `protobuf:"fixed64,3,opt,name=total_expense,json=totalExpense,proto3" json:"total_expense,omitempty"`
+	Balance           float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	AverageIncome     float64                `protobuf:"fixed64,5,opt,name=average_income,json=averageIncome,proto3" json:"average_income,omitempty"`
+	AverageExpense    float64                `protobuf:"fixed64,6,opt,name=average_expense,json=averageExpense,proto3" json:"average_expense,omitempty"`
+	ExpenseByCategory map[string]float64     `protobuf:"bytes,7,rep,name=expense_by_category,json=expenseByCategory,proto3" json:"expense_by_category,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	IncomeByCategory  map[string]float64     `protobuf:"bytes,8,rep,name=income_by_category,json=incomeByCategory,proto3" json:"income_by_category,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	TransactionsCount int64                  `protobuf:"varint,9,opt,name=transactions_count,json=transactionsCount,proto3" json:"transactions_count,omitempty"`
+	GeneratedAt       string                 `protobuf:"bytes,10,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// Set when fin-api was unavailable and the last cached stats were
+	// served instead.
+	Stale         bool `protobuf:"varint,11,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinanceStats) Reset() {
+	*x = FinanceStats{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinanceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinanceStats) ProtoMessage() {}
+
+func (x *FinanceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinanceStats.ProtoReflect.Descriptor instead.
+func (*FinanceStats) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FinanceStats) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetTotalIncome() float64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetTotalExpense() float64 {
+	if x != nil {
+		return x.TotalExpense
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetAverageIncome() float64 {
+	if x != nil {
+		return x.AverageIncome
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetAverageExpense() float64 {
+	if x != nil {
+		return x.AverageExpense
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetExpenseByCategory() map[string]float64 {
+	if x != nil {
+		return x.ExpenseByCategory
+	}
+	return nil
+}
+
+func (x *FinanceStats) GetIncomeByCategory() map[string]float64 {
+	if x != nil {
+		return x.IncomeByCategory
+	}
+	return nil
+}
+
+func (x *FinanceStats) GetTransactionsCount() int64 {
+	if x != nil {
+		return x.TransactionsCount
+	}
+	return 0
+}
+
+func (x *FinanceStats) GetGeneratedAt() string {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return ""
+}
+
+func (x *FinanceStats) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+type TimeSeriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Interval Interval               `protobuf:"varint,2,opt,name=interval,proto3,enum=fintrack.v1.Interval" json:"interval,omitempty"`
+	// RFC3339; empty means unbounded. from is inclusive, to exclusive.
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeSeriesRequest) Reset() {
+	*x = TimeSeriesRequest{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSeriesRequest) ProtoMessage() {}
+
+func (x *TimeSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeriesRequest.ProtoReflect.Descriptor instead.
+func (*TimeSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TimeSeriesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TimeSeriesRequest) GetInterval() Interval {
+	if x != nil {
+		return x.Interval
+	}
+	return Interval_INTERVAL_UNSPECIFIED
+}
+
+func (x *TimeSeriesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TimeSeriesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type TimeSeriesPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the period in UTC, RFC3339. Weeks start on Monday.
+	PeriodStart       string  `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Income            float64 `protobuf:"fixed64,2,opt,name=income,proto3" json:"income,omitempty"`
+	Expense           float64 `protobuf:"fixed64,3,opt,name=expense,proto3" json:"expense,omitempty"`
+	Balance           float64 `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	TransactionsCount int64   `protobuf:"varint,5,opt,name=transactions_count,json=transactionsCount,proto3" json:"transactions_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TimeSeriesPoint) Reset() {
+	*x = TimeSeriesPoint{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSeriesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSeriesPoint) ProtoMessage() {}
+
+func (x *TimeSeriesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeriesPoint.ProtoReflect.Descriptor instead.
+func (*TimeSeriesPoint) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TimeSeriesPoint) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *TimeSeriesPoint) GetIncome() float64 {
+	if x != nil {
+		return x.Income
+	}
+	return 0
+}
+
+func (x *TimeSeriesPoint) GetExpense() float64 {
+	if x != nil {
+		return x.Expense
+	}
+	return 0
+}
+
+func (x *TimeSeriesPoint) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *TimeSeriesPoint) GetTransactionsCount() int64 {
+	if x != nil {
+		return x.TransactionsCount
+	}
+	return 0
+}
+
+type TimeSeries struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Interval Interval               `protobuf:"varint,2,opt,name=interval,proto3,enum=fintrack.v1.Interval" json:"interval,omitempty"`
+	// Periods without transactions are omitted.
+	Points        []*TimeSeriesPoint `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeSeries) Reset() {
+	*x = TimeSeries{}
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSeries) ProtoMessage() {}
+
+func (x *TimeSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_fintrack_v1_fintrack_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeries.ProtoReflect.Descriptor instead.
+func (*TimeSeries) Descriptor() ([]byte, []int) {
+	return file_fintrack_v1_fintrack_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TimeSeries) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TimeSeries) GetInterval() Interval {
+	if x != nil {
+		return x.Interval
+	}
+	return Interval_INTERVAL_UNSPECIFIED
+}
+
+func (x *TimeSeries) GetPoints() []*TimeSeriesPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+var File_fintrack_v1_fintrack_proto protoreflect.FileDescriptor
+
+const file_fintrack_v1_fintrack_proto_rawDesc = "" +
+	"\n" +
+	"\x1afintrack/v1/fintrack.proto\x12\vfintrack.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x1d\n" +
+	"\x05Error\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"&\n" +
+	"\vUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xae\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12(\n" +
+	"\auser_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"P\n" +
+	"\x10UserTransactions\x12<\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x18.fintrack.v1.TransactionR\ftransactions\"{\n" +
+	"\x18CreateTransactionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\"\x8b\x01\n" +
+	"\x18UpdateTransactionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\"=\n" +
+	"\x12TransactionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"\x1b\n" +
+	"\x19DeleteTransactionResponse\"\xc2\x01\n" +
+	"\x17ListTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"\x80\x01\n" +
+	"\x18ListTransactionsResponse\x12<\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x18.fintrack.v1.TransactionR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"'\n" +
+	"\fStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x8d\x05\n" +
+	"\fFinanceStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12!\n" +
+	"\ftotal_income\x18\x02 \x01(\x01R\vtotalIncome\x12#\n" +
+	"\rtotal_expense\x18\x03 \x01(\x01R\ftotalExpense\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\x12%\n" +
+	"\x0eaverage_income\x18\x05 \x01(\x01R\raverageIncome\x12'\n" +
+	"\x0faverage_expense\x18\x06 \x01(\x01R\x0eaverageExpense\x12`\n" +
+	"\x13expense_by_category\x18\a \x03(\v20.fintrack.v1.FinanceStats.ExpenseByCategoryEntryR\x11expenseByCategory\x12]\n" +
+	"\x12income_by_category\x18\b \x03(\v2/.fintrack.v1.FinanceStats.IncomeByCategoryEntryR\x10incomeByCategory\x12-\n" +
+	"\x12transactions_count\x18\t \x01(\x03R\x11transactionsCount\x12!\n" +
+	"\fgenerated_at\x18\n" +
+	" \x01(\tR\vgeneratedAt\x12\x14\n" +
+	"\x05stale\x18\v \x01(\bR\x05stale\x1aD\n" +
+	"\x16ExpenseByCategoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1aC\n" +
+	"\x15IncomeByCategoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x83\x01\n" +
+	"\x11TimeSeriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x121\n" +
+	"\binterval\x18\x02 \x01(\x0e2\x15.fintrack.v1.IntervalR\binterval\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\xaf\x01\n" +
+	"\x0fTimeSeriesPoint\x12!\n" +
+	"\fperiod_start\x18\x01 \x01(\tR\vperiodStart\x12\x16\n" +
+	"\x06income\x18\x02 \x01(\x01R\x06income\x12\x18\n" +
+	"\aexpense\x18\x03 \x01(\x01R\aexpense\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\x12-\n" +
+	"\x12transactions_count\x18\x05 \x01(\x03R\x11transactionsCount\"\x8e\x01\n" +
+	"\n" +
+	"TimeSeries\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x121\n" +
+	"\binterval\x18\x02 \x01(\x0e2\x15.fintrack.v1.IntervalR\binterval\x124\n" +
+	"\x06points\x18\x03 \x03(\v2\x1c.fintrack.v1.TimeSeriesPointR\x06points*]\n" +
+	"\bInterval\x12\x18\n" +
+	"\x14INTERVAL_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fINTERVAL_DAY\x10\x01\x12\x11\n" +
+	"\rINTERVAL_WEEK\x10\x02\x12\x12\n" +
+	"\x0eINTERVAL_MONTH\x10\x032\x9e\b\n" +
+	"\x12TransactionService\x12\x86\x01\n" +
+	"\x13GetUserTransactions\x12\x18.fintrack.v1.UserRequest\x1a\x1d.fintrack.v1.UserTransactions\"6\x82\xd3\xe4\x93\x020b\ftransactions\x12 /v1/users/{user_id}/transactions\x12S\n" +
+	"\x16StreamUserTransactions\x12\x18.fintrack.v1.UserRequest\x1a\x1d.fintrack.v1.UserTransactions0\x01\x12\xc4\x01\n" +
+	"\x11CreateTransaction\x12%.fintrack.v1.CreateTransactionRequest\x1a\x18.fintrack.v1.Transaction\"n\x92A@J>\n" +
+	"\x03201\x127\n" +
+	"\x17The created transaction\x12\x1c\n" +
+	"\x1a\x1a\x18.fintrack.v1.Transaction\x82\xd3\xe4\x93\x02%:\x01*\" /v1/users/{user_id}/transactions\x12z\n" +
+	"\x0eGetTransaction\x12\x1f.fintrack.v1.TransactionRequest\x1a\x18.fintrack.v1.Transaction\"-\x82\xd3\xe4\x93\x02'\x12%/v1/users/{user_id}/transactions/{id}\x12\xb2\x01\n" +
+	"\x11UpdateTransaction\x12%.fintrack.v1.UpdateTransactionRequest\x1a\x18.fintrack.v1.Transaction\"\\\x82\xd3\xe4\x93\x02V:\x01*Z*:\x01*2%/v1/users/{user_id}/transactions/{id}\x1a%/v1/users/{user_id}/transactions/{id}\x12\xa0\x01\n" +
+	"\x11DeleteTransaction\x12\x1f.fintrack.v1.TransactionRequest\x1a&.fintrack.v1.DeleteTransactionResponse\"B\x92A\x12J\x10\n" +
+	"\x03204\x12\t\n" +
+	"\aDeleted\x82\xd3\xe4\x93\x02'*%/v1/users/{user_id}/transactions/{id}\x12\x8e\x01\n" +
+	"\x10ListTransactions\x12$.fintrack.v1.ListTransactionsRequest\x1a%.fintrack.v1.ListTransactionsResponse\"-\x82\xd3\xe4\x93\x02'\x12%/v1/users/{user_id}/transactions:page2\xe4\x01\n" +
+	"\x10AnalyticsService\x12@\n" +
+	"\bGetStats\x12\x19.fintrack.v1.StatsRequest\x1a\x19.fintrack.v1.FinanceStats\x12H\n" +
+	"\rGetTimeSeries\x12\x1e.fintrack.v1.TimeSeriesRequest\x1a\x17.fintrack.v1.TimeSeries\x12D\n" +
+	"\n" +
+	"WatchStats\x12\x19.fintrack.v1.StatsRequest\x1a\x19.fintrack.v1.FinanceStats0\x01B\xf3\x01\x92A\xc8\x01\x12Q\n" +
+	"\x14FinTrack API Service\x122REST API for managing user financial transactions.2\x051.0.0R-\n" +
+	"\adefault\x12\"\n" +
+	"\bAn error\x12\x16\n" +
+	"\x14\x1a\x12.fintrack.v1.ErrorZD\n" +
+	"B\n" +
+	"\bAdminKey\x126\b\x02\x12!Bearer <key> with the admin scope\x1a\rAuthorization \x02Z%fin-shared/api/fintrack/v1;fintrackv1b\x06proto3"
+
+var (
+	file_fintrack_v1_fintrack_proto_rawDescOnce sync.Once
+	file_fintrack_v1_fintrack_proto_rawDescData []byte
+)
+
+func file_fintrack_v1_fintrack_proto_rawDescGZIP() []byte {
+	file_fintrack_v1_fintrack_proto_rawDescOnce.Do(func() {
+		file_fintrack_v1_fintrack_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fintrack_v1_fintrack_proto_rawDesc), len(file_fintrack_v1_fintrack_proto_rawDesc)))
+	})
+	return file_fintrack_v1_fintrack_proto_rawDescData
+}
+
+var file_fintrack_v1_fintrack_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_fintrack_v1_fintrack_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_fintrack_v1_fintrack_proto_goTypes = []any{
+	(Interval)(0),                     // 0: fintrack.v1.Interval
+	(*Error)(nil),                     // 1: fintrack.v1.Error
+	(*UserRequest)(nil),               // 2: fintrack.v1.UserRequest
+	(*Transaction)(nil),               // 3: fintrack.v1.Transaction
+	(*UserTransactions)(nil),          // 4: fintrack.v1.UserTransactions
+	(*CreateTransactionRequest)(nil),  // 5: fintrack.v1.CreateTransactionRequest
+	(*UpdateTransactionRequest)(nil),  // 6: fintrack.v1.UpdateTransactionRequest
+	(*TransactionRequest)(nil),        // 7: fintrack.v1.TransactionRequest
+	(*DeleteTransactionResponse)(nil), // 8: fintrack.v1.DeleteTransactionResponse
+	(*ListTransactionsRequest)(nil),   // 9: fintrack.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),  // 10: fintrack.v1.ListTransactionsResponse
+	(*StatsRequest)(nil),              // 11: fintrack.v1.StatsRequest
+	(*FinanceStats)(nil),              // 12: fintrack.v1.FinanceStats
+	(*TimeSeriesRequest)(nil),         // 13: fintrack.v1.TimeSeriesRequest
+	(*TimeSeriesPoint)(nil),           // 14: fintrack.v1.TimeSeriesPoint
+	(*TimeSeries)(nil),                // 15: fintrack.v1.TimeSeries
+	nil,                               // 16: fintrack.v1.FinanceStats.ExpenseByCategoryEntry
+	nil,                               // 17: fintrack.v1.FinanceStats.IncomeByCategoryEntry
+}
+var file_fintrack_v1_fintrack_proto_depIdxs = []int32{
+	3,  // 0: fintrack.v1.UserTransactions.transactions:type_name -> fintrack.v1.Transaction
+	3,  // 1: fintrack.v1.ListTransactionsResponse.transactions:type_name -> fintrack.v1.Transaction
+	16, // 2: fintrack.v1.FinanceStats.expense_by_category:type_name -> fintrack.v1.FinanceStats.ExpenseByCategoryEntry
+	17, // 3: fintrack.v1.FinanceStats.income_by_category:type_name -> fintrack.v1.FinanceStats.IncomeByCategoryEntry
+	0,  // 4: fintrack.v1.TimeSeriesRequest.interval:type_name -> fintrack.v1.Interval
+	0,  // 5: fintrack.v1.TimeSeries.interval:type_name -> fintrack.v1.Interval
+	14, // 6: fintrack.v1.TimeSeries.points:type_name -> fintrack.v1.TimeSeriesPoint
+	2,  // 7: fintrack.v1.TransactionService.GetUserTransactions:input_type -> fintrack.v1.UserRequest
+	2,  // 8: fintrack.v1.TransactionService.StreamUserTransactions:input_type -> fintrack.v1.UserRequest
+	5,  // 9: fintrack.v1.TransactionService.CreateTransaction:input_type -> fintrack.v1.CreateTransactionRequest
+	7,  // 10: fintrack.v1.TransactionService.GetTransaction:input_type -> fintrack.v1.TransactionRequest
+	6,  // 11: fintrack.v1.TransactionService.UpdateTransaction:input_type -> fintrack.v1.UpdateTransactionRequest
+	7,  // 12: fintrack.v1.TransactionService.DeleteTransaction:input_type -> fintrack.v1.TransactionRequest
+	9,  // 13: fintrack.v1.TransactionService.ListTransactions:input_type -> fintrack.v1.ListTransactionsRequest
+	11, // 14: fintrack.v1.AnalyticsService.GetStats:input_type -> fintrack.v1.StatsRequest
+	13, // 15: fintrack.v1.AnalyticsService.GetTimeSeries:input_type -> fintrack.v1.TimeSeriesRequest
+	11, // 16: fintrack.v1.AnalyticsService.WatchStats:input_type -> fintrack.v1.StatsRequest
+	4,  // 17: fintrack.v1.TransactionService.GetUserTransactions:output_type -> fintrack.v1.UserTransactions
+	4,  // 18: fintrack.v1.TransactionService.StreamUserTransactions:output_type -> fintrack.v1.UserTransactions
+	3,  // 19: fintrack.v1.TransactionService.CreateTransaction:output_type -> fintrack.v1.Transaction
+	3,  // 20: fintrack.v1.TransactionService.GetTransaction:output_type -> fintrack.v1.Transaction
+	3,  // 21: fintrack.v1.TransactionService.UpdateTransaction:output_type -> fintrack.v1.Transaction
+	8,  // 22: fintrack.v1.TransactionService.DeleteTransaction:output_type -> fintrack.v1.DeleteTransactionResponse
+	10, // 23: fintrack.v1.TransactionService.ListTransactions:output_type -> fintrack.v1.ListTransactionsResponse
+	12, // 24: fintrack.v1.AnalyticsService.GetStats:output_type -> fintrack.v1.FinanceStats
+	15, // 25: fintrack.v1.AnalyticsService.GetTimeSeries:output_type -> fintrack.v1.TimeSeries
+	12, // 26: fintrack.v1.AnalyticsService.WatchStats:output_type -> fintrack.v1.FinanceStats
+	17, // [17:27] is the sub-list for method output_type
+	7,  // [7:17] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_fintrack_v1_fintrack_proto_init() }
+func file_fintrack_v1_fintrack_proto_init() {
+	if File_fintrack_v1_fintrack_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fintrack_v1_fintrack_proto_rawDesc), len(file_fintrack_v1_fintrack_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_fintrack_v1_fintrack_proto_goTypes,
+		DependencyIndexes: file_fintrack_v1_fintrack_proto_depIdxs,
+		EnumInfos:         file_fintrack_v1_fintrack_proto_enumTypes,
+		MessageInfos:      file_fintrack_v1_fintrack_proto_msgTypes,
+	}.Build()
+	File_fintrack_v1_fintrack_proto = out.File
+	file_fintrack_v1_fintrack_proto_goTypes = nil
+	file_fintrack_v1_fintrack_proto_depIdxs = nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"slices"
+
+	"fin-shared/config"
+)
+
+const (
+	ScopeAdmin = "admin"
+	// ScopeStats allows reading stats from the fin-analytics gRPC API.
+	ScopeStats = "stats"
+)
+
+type Principal struct {
+	Name   string
+	Scopes []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal Principal
+}
+
+// Authenticator resolves static API keys from config to principals.
+type Authenticator struct {
+	keys []apiKey
+}
+
+func New(keys []config.APIKeyConfig) *Authenticator {
+	a := &Authenticator{}
+	for _, key := range keys {
+		if key.Key == "" {
+			continue
+		}
+		a.keys = append(a.keys, apiKey{
+			hash:      sha256.Sum256([]byte(key.Key)),
+			principal: Principal{Name: key.Name, Scopes: key.Scopes},
+		})
+	}
+	return a
+}
+
+// Authenticate compares hashes in constant time so that the lookup doesn't
+// leak key prefixes through timing.
+func (a *Authenticator) Authenticate(token string) (Principal, bool) {
+	hash := sha256.Sum256([]byte(token))
+	for _, key := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], key.hash[:]) == 1 {
+			return key.principal, true
+		}
+	}
+	return Principal{}, false
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"fin-shared/config"
+)
+
+func TestAuthenticate(t *testing.T) {
+	a := New([]config.APIKeyConfig{
+		{Name: "ops", Key: "secret-ops", Scopes: []string{ScopeAdmin}},
+		{Name: "reporting", Key: "secret-reporting"},
+		{Name: "disabled", Key: ""},
+	})
+
+	principal, ok := a.Authenticate("secret-ops")
+	assert.True(t, ok)
+	assert.Equal(t, "ops", principal.Name)
+	assert.True(t, principal.HasScope(ScopeAdmin))
+
+	principal, ok = a.Authenticate("secret-reporting")
+	assert.True(t, ok)
+	assert.False(t, principal.HasScope(ScopeAdmin))
+
+	_, ok = a.Authenticate("")
+	assert.False(t, ok)
+	_, ok = a.Authenticate("secret")
+	assert.False(t, ok)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	a := New([]config.APIKeyConfig{
+		{Name: "reporting", Key: "secret-reporting", Scopes: []string{ScopeStats}},
+		{Name: "ops", Key: "secret-ops", Scopes: []string{ScopeAdmin}},
+	})
+	interceptor := a.UnaryServerInterceptor(ScopeStats)
+	handler := func(ctx context.Context, req any) (any, error) {
+		principal, _ := PrincipalFromContext(ctx)
+		return principal.Name, nil
+	}
+	call := func(md metadata.MD) (any, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+	}
+
+	resp, err := call(metadata.Pairs("authorization", "Bearer secret-reporting"))
+	require.NoError(t, err)
+	assert.Equal(t, "reporting", resp)
+
+	_, err = call(metadata.MD{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = call(metadata.Pairs("authorization", "Bearer wrong"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = call(metadata.Pairs("authorization", "Bearer secret-ops"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
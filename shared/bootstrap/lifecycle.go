@@ -0,0 +1,124 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// Hook is one component of the application. All fields are optional.
+type Hook struct {
+	Name string
+	// OnStart runs during startup, in registration order. A failure aborts
+	// startup and stops the hooks that already started.
+	OnStart func(ctx context.Context) error
+	// Run is a long-running task such as a server. It is started once every
+	// OnStart succeeded and must return after OnStop is called. Returning
+	// earlier, with or without an error, shuts the app down.
+	Run func() error
+	// OnStop runs during shutdown, in reverse registration order, under the
+	// shared shutdown deadline.
+	OnStop func(ctx context.Context) error
+}
+
+// App starts hooks in order and stops them in reverse, so components
+// registered last (servers) stop taking work before the ones they depend on
+// (producers, pools) are closed.
+type App struct {
+	hooks           []Hook
+	shutdownTimeout time.Duration
+}
+
+func New(shutdownTimeout time.Duration) *App {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	return &App{shutdownTimeout: shutdownTimeout}
+}
+
+func (a *App) Append(hook Hook) {
+	a.hooks = append(a.hooks, hook)
+}
+
+// Abort stops every registered hook and returns err joined with any
+// errors from stopping. It is meant for failures while wiring the app,
+// before Run, so that resources created so far are still released.
+func (a *App) Abort(err error) error {
+	return errors.Join(err, a.stop(a.hooks))
+}
+
+type runResult struct {
+	name string
+	err  error
+}
+
+// Run starts the app and blocks until ctx is cancelled, SIGINT or SIGTERM is
+// received, or a Run task exits. It then stops every started hook and returns
+// the error that caused the shutdown joined with any errors from stopping.
+func (a *App) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	for i, hook := range a.hooks {
+		if hook.OnStart == nil {
+			continue
+		}
+		if err := hook.OnStart(ctx); err != nil {
+			return errors.Join(fmt.Errorf("start %s: %w", hook.Name, err), a.stop(a.hooks[:i]))
+		}
+	}
+
+	results := make(chan runResult, len(a.hooks))
+	for _, hook := range a.hooks {
+		if hook.Run == nil {
+			continue
+		}
+		go func() {
+			results <- runResult{name: hook.Name, err: hook.Run()}
+		}()
+	}
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received")
+	case res := <-results:
+		if res.err != nil {
+			cause = fmt.Errorf("%s: %w", res.name, res.err)
+			slog.Error("Component failed, shutting down", "component", res.name, "error", res.err)
+		} else {
+			slog.Warn("Component exited, shutting down", "component", res.name)
+		}
+	}
+
+	return errors.Join(cause, a.stop(a.hooks))
+}
+
+func (a *App) stop(hooks []Hook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		start := time.Now()
+		if err := hook.OnStop(ctx); err != nil {
+			slog.Error("Failed to stop component", "component", hook.Name, "error", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+			continue
+		}
+		slog.Info("Component stopped", "component", hook.Name, "duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
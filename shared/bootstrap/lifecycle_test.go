@@ -0,0 +1,111 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) hook(name string) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	rec := &recorder{}
+	app := New(time.Second)
+	app.Append(rec.hook("pools"))
+	app.Append(rec.hook("producer"))
+	app.Append(rec.hook("server"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, app.Run(ctx))
+
+	assert.Equal(t, []string{
+		"start pools", "start producer", "start server",
+		"stop server", "stop producer", "stop pools",
+	}, rec.events)
+}
+
+func TestRunShutsDownWhenTaskFails(t *testing.T) {
+	rec := &recorder{}
+	stopped := make(chan struct{})
+	app := New(time.Second)
+	app.Append(rec.hook("pools"))
+	app.Append(Hook{
+		Name: "http",
+		Run:  func() error { return errors.New("address already in use") },
+	})
+	app.Append(Hook{
+		Name: "grpc",
+		Run: func() error {
+			<-stopped
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			rec.add("stop grpc")
+			close(stopped)
+			return nil
+		},
+	})
+
+	err := app.Run(context.Background())
+	assert.ErrorContains(t, err, "http: address already in use")
+	assert.Equal(t, []string{"start pools", "stop grpc", "stop pools"}, rec.events)
+}
+
+func TestRunStopsStartedHooksWhenStartupFails(t *testing.T) {
+	rec := &recorder{}
+	app := New(time.Second)
+	app.Append(rec.hook("pools"))
+	app.Append(Hook{
+		Name:    "migrations",
+		OnStart: func(context.Context) error { return errors.New("checksum mismatch") },
+	})
+	app.Append(rec.hook("server"))
+
+	err := app.Run(context.Background())
+	assert.ErrorContains(t, err, "start migrations: checksum mismatch")
+	assert.Equal(t, []string{"start pools", "stop pools"}, rec.events)
+}
+
+func TestStopHonoursDeadline(t *testing.T) {
+	app := New(20 * time.Millisecond)
+	app.Append(Hook{
+		Name: "slow",
+		OnStop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, app.Run(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,224 @@
+// Package certs serves TLS certificates from files that are replaced on
+// rotation, such as Kubernetes secrets or the output of cert-manager.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"fin-shared/config"
+)
+
+// reloadDebounce waits for the key, certificate and CA of one rotation to
+// all be written before reading them.
+const reloadDebounce = 500 * time.Millisecond
+
+type material struct {
+	cert *tls.Certificate
+	// roots is nil when no CA file is configured.
+	roots *x509.CertPool
+}
+
+// Reloader keeps the certificate, key and CA from config and swaps them
+// when the files change. The tls.Configs it returns read the current
+// ones on every handshake, so connections opened after a rotation use
+// the new files while open ones are left alone.
+type Reloader struct {
+	cfg      config.TLSConfig
+	current  atomic.Pointer[material]
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func New(cfg config.TLSConfig) (*Reloader, error) {
+	r := &Reloader{cfg: cfg, stop: make(chan struct{})}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again. On error the previous certificates stay
+// in use.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertPath, r.cfg.KeyPath)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+	m := &material{cert: &cert}
+	if r.cfg.CAPath != "" {
+		pem, err := os.ReadFile(r.cfg.CAPath)
+		if err != nil {
+			return fmt.Errorf("read tls ca: %w", err)
+		}
+		m.roots = x509.NewCertPool()
+		if !m.roots.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls ca %s: no certificates found", r.cfg.CAPath)
+		}
+	}
+	r.current.Store(m)
+	return nil
+}
+
+// ServerConfig is for HTTP and gRPC servers. With clientAuth the client
+// must present a certificate signed by the CA; it is meant for the gRPC
+// listeners, since probes, scrapes and REST clients on the HTTP one have
+// no certificate.
+func (r *Reloader) ServerConfig(clientAuth bool) *tls.Config {
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.current.Load().cert, nil
+		},
+	}
+	if clientAuth {
+		// The CA may rotate, so the chain is verified against the current
+		// pool in VerifyConnection instead of a fixed ClientCAs.
+		c.ClientAuth = tls.RequireAnyClientCert
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return r.verify(cs, "", x509.ExtKeyUsageClientAuth)
+		}
+	}
+	return c
+}
+
+// ClientConfig presents the certificate to servers that require one and
+// verifies the server against the CA. The server name is taken from the
+// dialed address unless the caller sets ServerName.
+func (r *Reloader) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.current.Load().cert, nil
+		},
+		// Verification is not skipped: VerifyConnection checks the chain
+		// and the host name against the current CA, which a fixed RootCAs
+		// could not follow through a rotation.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return r.verify(cs, cs.ServerName, x509.ExtKeyUsageServerAuth)
+		},
+	}
+}
+
+func (r *Reloader) verify(cs tls.ConnectionState, dnsName string, usage x509.ExtKeyUsage) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: peer sent no certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         r.current.Load().roots,
+		DNSName:       dnsName,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// Run reloads the files when they change until Stop is called. Like the
+// config watcher it watches their directories, so renames and Kubernetes
+// symlink swaps are noticed. If they can't be watched the certificates
+// loaded at startup stay in use.
+func (r *Reloader) Run() error {
+	fsw, err := r.watch()
+	if err != nil {
+		slog.Warn("Certificate watching disabled, rotation needs a restart", "error", err)
+		<-r.stop
+		return nil
+	}
+	defer fsw.Close()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-r.stop:
+			return nil
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if r.affects(event) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("Certificate watcher error", "error", err)
+		case <-debounce:
+			debounce = nil
+			if err := r.Reload(); err != nil {
+				slog.Error("Certificate reload failed, keeping the previous certificates", "error", err)
+				continue
+			}
+			slog.Info("Certificates reloaded", "cert_path", r.cfg.CertPath, "not_after", r.current.Load().cert.Leaf.NotAfter)
+		}
+	}
+}
+
+func (r *Reloader) watch() (*fsnotify.Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create certificate watcher: %w", err)
+	}
+	for _, dir := range r.dirs() {
+		if err := fsw.Add(dir); err != nil {
+			fsw.Close()
+			return nil, fmt.Errorf("watch %s: %w", dir, err)
+		}
+	}
+	return fsw, nil
+}
+
+func (r *Reloader) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertPath, r.cfg.KeyPath}
+	if r.cfg.CAPath != "" {
+		files = append(files, r.cfg.CAPath)
+	}
+	return files
+}
+
+func (r *Reloader) dirs() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, file := range r.files() {
+		dir := filepath.Dir(file)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func (r *Reloader) affects(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	if filepath.Base(event.Name) == "..data" {
+		return true
+	}
+	for _, file := range r.files() {
+		if filepath.Clean(event.Name) == filepath.Clean(file) {
+			return true
+		}
+	}
+	return false
+}
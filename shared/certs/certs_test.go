@@ -0,0 +1,207 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-shared/config"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newAuthority(t *testing.T) authority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fintrack test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return authority{cert: cert, key: key}
+}
+
+// issue writes a certificate for name signed by ca, plus the CA itself,
+// into dir and returns the matching config.
+func (ca authority) issue(t *testing.T, dir, name string) config.TLSConfig {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cfg := config.TLSConfig{
+		Enabled:  true,
+		CertPath: filepath.Join(dir, name+".crt"),
+		KeyPath:  filepath.Join(dir, name+".key"),
+		CAPath:   filepath.Join(dir, "ca.crt"),
+	}
+	writePEM(t, cfg.CertPath, "CERTIFICATE", der)
+	writePEM(t, cfg.KeyPath, "EC PRIVATE KEY", keyDER)
+	writePEM(t, cfg.CAPath, "CERTIFICATE", ca.cert.Raw)
+	return cfg
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+// handshake runs a TLS handshake over loopback and returns the client and
+// server errors. A pipe would not do: the server writes session tickets
+// that nobody reads.
+func handshake(server, client *tls.Config) (error, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err, err
+	}
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- tls.Server(conn, server).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), client)
+	if err == nil {
+		conn.Close()
+	}
+	return err, <-serverErr
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newAuthority(t)
+	server, err := New(ca.issue(t, t.TempDir(), "fin-api"))
+	require.NoError(t, err)
+	client, err := New(ca.issue(t, t.TempDir(), "fin-analytics"))
+	require.NoError(t, err)
+
+	clientTLS := client.ClientConfig()
+	clientTLS.ServerName = "fin-api"
+	clientErr, serverErr := handshake(server.ServerConfig(true), clientTLS)
+	assert.NoError(t, clientErr)
+	assert.NoError(t, serverErr)
+
+	// The client checks the host name.
+	clientTLS.ServerName = "fin-analytics"
+	clientErr, _ = handshake(server.ServerConfig(true), clientTLS)
+	assert.Error(t, clientErr)
+
+	// A client without a certificate is rejected.
+	_, serverErr = handshake(server.ServerConfig(true), &tls.Config{ServerName: "fin-api", RootCAs: certPool(ca)})
+	assert.Error(t, serverErr)
+
+	// So is one signed by another CA.
+	stranger, err := New(newAuthority(t).issue(t, t.TempDir(), "fin-analytics"))
+	require.NoError(t, err)
+	strangerTLS := stranger.ClientConfig()
+	strangerTLS.ServerName = "fin-api"
+	_, serverErr = handshake(server.ServerConfig(true), strangerTLS)
+	assert.Error(t, serverErr)
+}
+
+func TestHTTPSWithoutClientCertificate(t *testing.T) {
+	ca := newAuthority(t)
+	cfg := ca.issue(t, t.TempDir(), "fin-api")
+	cfg.ClientAuth = true
+	server, err := New(cfg)
+	require.NoError(t, err)
+
+	// Probes and scrapes reach the HTTP listener without a certificate
+	// even where the gRPC one requires it.
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = server.ServerConfig(false)
+	srv.StartTLS()
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{ServerName: "fin-api", RootCAs: certPool(ca)}}}
+	resp, err := client.Get(srv.URL + "/livez")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRunReloadsRotatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	oldCA, newCA := newAuthority(t), newAuthority(t)
+	cfg := oldCA.issue(t, dir, "fin-api")
+	server, err := New(cfg)
+	require.NoError(t, err)
+	go func() { _ = server.Run() }()
+	t.Cleanup(server.Stop)
+
+	trusts := func(ca authority) bool {
+		clientErr, _ := handshake(server.ServerConfig(false), &tls.Config{ServerName: "fin-api", RootCAs: certPool(ca)})
+		return clientErr == nil
+	}
+	assert.True(t, trusts(oldCA))
+
+	// Give the watcher time to start before rotating.
+	time.Sleep(100 * time.Millisecond)
+	newCA.issue(t, dir, "fin-api")
+	assert.Eventually(t, func() bool { return trusts(newCA) }, 5*time.Second, 50*time.Millisecond)
+	assert.False(t, trusts(oldCA))
+}
+
+func TestReloadKeepsCertificatesOnError(t *testing.T) {
+	ca := newAuthority(t)
+	cfg := ca.issue(t, t.TempDir(), "fin-api")
+	server, err := New(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(cfg.KeyPath, []byte("half-written"), 0o600))
+	assert.Error(t, server.Reload())
+
+	clientErr, _ := handshake(server.ServerConfig(false), &tls.Config{ServerName: "fin-api", RootCAs: certPool(ca)})
+	assert.NoError(t, clientErr)
+}
+
+func certPool(ca authority) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
@@ -0,0 +1,525 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+type Config struct {
+	App struct {
+		Env        string `mapstructure:"env"`
+		KafkaTopic string `mapstructure:"kafka_topic"`
+	} `mapstructure:"app"`
+
+	FinAPI struct {
+		HTTPHost   string `mapstructure:"http_host"`
+		HTTPPort   int    `mapstructure:"http_port"`
+		GRPCHost   string `mapstructure:"grpc_host"`
+		GRPCPort   int    `mapstructure:"grpc_port"`
+		GRPCTarget string `mapstructure:"grpc_target"`
+		// GRPCEndpoints, when set, replaces GRPCTarget with a static list
+		// of fin-api instances.
+		GRPCEndpoints []string         `mapstructure:"grpc_endpoints"`
+		GRPCClient    GRPCClientConfig `mapstructure:"grpc_client"`
+		// NodeID is stored in every transaction ID this instance creates
+		// and must be unique among the Replicas instances: one of 0 to
+		// Replicas-1. Only a single instance may leave it unset, which
+		// means 0.
+		NodeID   int `mapstructure:"node_id"`
+		Replicas int `mapstructure:"replicas"`
+	} `mapstructure:"fin_api"`
+
+	FinAnalytics struct {
+		HTTPHost string `mapstructure:"http_host"`
+		HTTPPort int    `mapstructure:"http_port"`
+		GRPCHost string `mapstructure:"grpc_host"`
+		GRPCPort int    `mapstructure:"grpc_port"`
+	} `mapstructure:"fin_analytics"`
+
+	Postgres    PostgresConfig    `mapstructure:"postgres"`
+	AnalyticsDB AnalyticsDBConfig `mapstructure:"analytics_db"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	Cache       CacheConfig       `mapstructure:"cache"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	Features    Features          `mapstructure:"features"`
+	Kafka       KafkaConfig       `mapstructure:"kafka"`
+	Auth        AuthConfig        `mapstructure:"auth"`
+	TLS         TLSConfig         `mapstructure:"tls"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+	Logging     LoggingConfig     `mapstructure:"logging"`
+	Readiness   ReadinessConfig   `mapstructure:"readiness"`
+	Shutdown    ShutdownConfig    `mapstructure:"shutdown"`
+}
+
+type CacheConfig struct {
+	// TTL is how long computed stats stay in Redis.
+	TTL time.Duration `mapstructure:"ttl"`
+	// SoftTTL is the age after which cached stats are still served but
+	// recomputed in the background, so popular keys rarely expire. Zero
+	// leaves stats in the cache until they expire.
+	SoftTTL time.Duration `mapstructure:"soft_ttl"`
+	// StaleTTL is how long a copy of the stats is kept to answer requests
+	// while fin-api is unreachable.
+	StaleTTL time.Duration    `mapstructure:"stale_ttl"`
+	Local    LocalCacheConfig `mapstructure:"local"`
+}
+
+// LocalCacheConfig bounds the in-memory tier each fin-analytics replica
+// keeps in front of Redis.
+type LocalCacheConfig struct {
+	// MaxEntries of zero turns the tier off.
+	MaxEntries int `mapstructure:"max_entries"`
+	// MaxBytes is an estimate of the memory the stats take, not a hard
+	// limit on the process.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+	// TTL bounds how long a replica may serve stats whose invalidation it
+	// missed, for example while its pub/sub connection was down.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// GRPCClientConfig tunes the fin-analytics client of fin-api.
+type GRPCClientConfig struct {
+	// Timeout is the deadline of each call, including every retry.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// LoadBalancing is round_robin or pick_first.
+	LoadBalancing string          `mapstructure:"load_balancing"`
+	Retry         RetryConfig     `mapstructure:"retry"`
+	Keepalive     KeepaliveConfig `mapstructure:"keepalive"`
+	Breaker       BreakerConfig   `mapstructure:"breaker"`
+}
+
+// RetryConfig is the gRPC retry policy of the idempotent reads. A
+// MaxAttempts of 1 disables retries.
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+}
+
+type KeepaliveConfig struct {
+	// Time is the idle period after which the client pings fin-api. It
+	// must not be below the 10s fin-api accepts.
+	Time    time.Duration `mapstructure:"time"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// BreakerConfig opens the circuit after FailureThreshold consecutive
+// failed calls; after OpenTimeout one trial call is let through.
+type BreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	OpenTimeout      time.Duration `mapstructure:"open_timeout"`
+}
+
+// RateLimitConfig limits requests per user. A zero RequestsPerSecond
+// disables the limit.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+// Features are runtime toggles, keyed by name.
+type Features map[string]bool
+
+const (
+	// FeatureAdminAPI serves the fin-api /admin routes.
+	FeatureAdminAPI = "admin_api"
+	// FeatureStatsCache lets fin-analytics answer stats requests from
+	// Redis; when off every request recomputes stats from fin-api.
+	FeatureStatsCache = "stats_cache"
+)
+
+func (f Features) Enabled(name string) bool {
+	return f[name]
+}
+
+type ReadinessConfig struct {
+	// Timeout bounds each readiness check.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type ShutdownConfig struct {
+	// Timeout bounds the whole graceful shutdown; components still
+	// draining when it expires are stopped forcibly.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// DrainDelay is how long the instance reports not ready before its
+	// servers stop, so that probes and load balancers notice first. It is
+	// part of Timeout.
+	DrainDelay time.Duration `mapstructure:"drain_delay"`
+}
+
+type LoggingConfig struct {
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+// TLSConfig turns on TLS for the service's HTTP and gRPC servers and, in
+// fin-analytics, for the client to fin-api. The files are reloaded when
+// they change on disk. The keys end in _path rather than _file because
+// *_file keys are read once at startup as secrets.
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertPath string `mapstructure:"cert_path"`
+	KeyPath  string `mapstructure:"key_path"`
+	// CAPath verifies peer certificates: the client certificates of the
+	// gRPC server when ClientAuth is set, and fin-api's certificate in
+	// fin-analytics.
+	CAPath string `mapstructure:"ca_path"`
+	// ClientAuth makes the gRPC server require a client certificate
+	// signed by CAPath (mutual TLS). HTTP clients still use API keys.
+	ClientAuth bool `mapstructure:"client_auth"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of none, otlp, stdout or memory.
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type AuthConfig struct {
+	APIKeys []APIKeyConfig `mapstructure:"api_keys"`
+}
+
+type APIKeyConfig struct {
+	Name   string   `mapstructure:"name"`
+	Key    string   `mapstructure:"key"`
+	Scopes []string `mapstructure:"scopes"`
+}
+
+type PostgresShardConfig struct {
+	Name        string     `mapstructure:"name"`
+	ConnURL     string     `mapstructure:"conn_url"`
+	ReplicaURLs []string   `mapstructure:"replica_urls"`
+	Buckets     int        `mapstructure:"buckets"`
+	Pool        PoolConfig `mapstructure:"pool"`
+}
+
+// PoolConfig tunes a pgxpool. Zero fields in a shard's pool section fall
+// back to postgres.pool.
+type PoolConfig struct {
+	MaxConns          int32         `mapstructure:"max_conns"`
+	MinConns          int32         `mapstructure:"min_conns"`
+	MaxConnLifetime   time.Duration `mapstructure:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `mapstructure:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `mapstructure:"health_check_period"`
+	ConnectTimeout    time.Duration `mapstructure:"connect_timeout"`
+}
+
+func (p PoolConfig) WithDefaults(defaults PoolConfig) PoolConfig {
+	if p.MaxConns == 0 {
+		p.MaxConns = defaults.MaxConns
+	}
+	if p.MinConns == 0 {
+		p.MinConns = defaults.MinConns
+	}
+	if p.MaxConnLifetime == 0 {
+		p.MaxConnLifetime = defaults.MaxConnLifetime
+	}
+	if p.MaxConnIdleTime == 0 {
+		p.MaxConnIdleTime = defaults.MaxConnIdleTime
+	}
+	if p.HealthCheckPeriod == 0 {
+		p.HealthCheckPeriod = defaults.HealthCheckPeriod
+	}
+	if p.ConnectTimeout == 0 {
+		p.ConnectTimeout = defaults.ConnectTimeout
+	}
+	return p
+}
+
+type PostgresConfig struct {
+	Shards      []PostgresShardConfig `mapstructure:"shards"`
+	Pool        PoolConfig            `mapstructure:"pool"`
+	Directory   DirectoryConfig       `mapstructure:"directory"`
+	Replicas    ReplicaConfig         `mapstructure:"replicas"`
+	Health      HealthCheckConfig     `mapstructure:"health"`
+	AutoMigrate bool                  `mapstructure:"auto_migrate"`
+	// SSLMode is added to connection URLs that don't set sslmode
+	// themselves.
+	SSLMode string `mapstructure:"sslmode"`
+	// ScatterConcurrency bounds the number of buckets queried in parallel
+	// by cross-shard admin queries.
+	ScatterConcurrency int `mapstructure:"scatter_concurrency"`
+}
+
+// AnalyticsDBConfig is the Postgres database fin-analytics keeps its
+// precomputed aggregates in. It is unrelated to the fin-api shards and may
+// live on any of them, in its own schema.
+type AnalyticsDBConfig struct {
+	ConnURL string `mapstructure:"conn_url"`
+	// Schema holds the fin-analytics tables; it is created by the
+	// migrations.
+	Schema      string     `mapstructure:"schema"`
+	SSLMode     string     `mapstructure:"sslmode"`
+	AutoMigrate bool       `mapstructure:"auto_migrate"`
+	Pool        PoolConfig `mapstructure:"pool"`
+}
+
+type HealthCheckConfig struct {
+	Interval         time.Duration `mapstructure:"interval"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+}
+
+type ReplicaConfig struct {
+	MaxLag           time.Duration `mapstructure:"max_lag"`
+	LagCheckInterval time.Duration `mapstructure:"lag_check_interval"`
+	// ReadYourWritesWindow is how long reads of a user go to the primary
+	// after the user wrote through the same fin-api instance.
+	ReadYourWritesWindow time.Duration `mapstructure:"read_your_writes_window"`
+}
+
+type DirectoryConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	ConnURL         string        `mapstructure:"conn_url"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func (p PostgresConfig) GetTotalBuckets() int {
+	total := 0
+	for _, shard := range p.Shards {
+		if shard.Buckets <= 0 {
+			shard.Buckets = 1
+		}
+		total += shard.Buckets
+	}
+	return total
+}
+
+// nodeIDUnset is the default of fin_api.node_id, which a deployment of
+// more than one fin-api instance must override.
+const nodeIDUnset = -1
+
+// applyNodeID gives a single fin-api instance node 0 when none is set.
+func (c *Config) applyNodeID() {
+	if c.FinAPI.NodeID == nodeIDUnset && c.FinAPI.Replicas == 1 {
+		c.FinAPI.NodeID = 0
+	}
+}
+
+func (p *PostgresConfig) applySSLMode() {
+	for i := range p.Shards {
+		shard := &p.Shards[i]
+		shard.ConnURL = withSSLMode(shard.ConnURL, p.SSLMode)
+		for j := range shard.ReplicaURLs {
+			shard.ReplicaURLs[j] = withSSLMode(shard.ReplicaURLs[j], p.SSLMode)
+		}
+	}
+	p.Directory.ConnURL = withSSLMode(p.Directory.ConnURL, p.SSLMode)
+}
+
+// withSSLMode adds sslmode to a postgres:// URL or a keyword/value
+// connection string unless it already sets one.
+func withSSLMode(conn, mode string) string {
+	if conn == "" || mode == "" {
+		return conn
+	}
+
+	u, err := url.Parse(conn)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		if strings.Contains(conn, "sslmode=") {
+			return conn
+		}
+		return conn + " sslmode=" + mode
+	}
+
+	query := u.Query()
+	if query.Has("sslmode") {
+		return conn
+	}
+	query.Set("sslmode", mode)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+type RedisConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+func (r RedisConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", r.Host, r.Port)
+}
+
+type KafkaConfig struct {
+	Brokers []string `mapstructure:"brokers"`
+	GroupID string   `mapstructure:"group_id"`
+}
+
+// Service selects the sections a service needs, for validation and for
+// --print-config.
+type Service string
+
+const (
+	FinAPI       Service = "fin-api"
+	FinAnalytics Service = "fin-analytics"
+)
+
+var serviceSections = map[Service][]string{
+	FinAPI:       {"app", "fin_api", "postgres", "kafka", "auth", "rate_limit", "features", "tls", "tracing", "logging", "readiness", "shutdown"},
+	FinAnalytics: {"app", "fin_api", "fin_analytics", "analytics_db", "redis", "cache", "kafka", "auth", "features", "tls", "tracing", "logging", "readiness", "shutdown"},
+}
+
+// Load reads the config file at path, applies defaults and FINTRACK_*
+// environment overrides, resolves secrets from files and validates the
+// result for service.
+func Load(path string, service Service) (*Config, error) {
+	settings, err := readSettings(path)
+	if err != nil {
+		return nil, err
+	}
+	return decode(settings, path, service)
+}
+
+// Print writes the effective config for service to w as YAML, with
+// secrets redacted. The config is printed even if it is invalid; the
+// validation error is returned afterwards.
+func Print(w io.Writer, path string, service Service) error {
+	settings, err := readSettings(path)
+	if err != nil {
+		return err
+	}
+
+	effective := make(map[string]any)
+	for _, section := range serviceSections[service] {
+		if value, ok := settings[section]; ok {
+			effective[section] = redact(value)
+		}
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(effective); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	_, err = decode(settings, path, service)
+	return err
+}
+
+func readSettings(path string) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	v.AutomaticEnv()
+	v.SetEnvPrefix("fintrack")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	v.SetDefault("app.env", "development")
+	v.SetDefault("app.kafka_topic", "user-transactions")
+	v.SetDefault("fin_api.http_host", "0.0.0.0")
+	v.SetDefault("fin_api.http_port", 8080)
+	v.SetDefault("fin_api.grpc_host", "0.0.0.0")
+	v.SetDefault("fin_api.grpc_port", 9090)
+	v.SetDefault("fin_api.grpc_target", "fin-api:9090")
+	v.SetDefault("fin_api.grpc_client.timeout", "10s")
+	v.SetDefault("fin_api.grpc_client.load_balancing", "round_robin")
+	v.SetDefault("fin_api.grpc_client.retry.max_attempts", 3)
+	v.SetDefault("fin_api.grpc_client.retry.initial_backoff", "100ms")
+	v.SetDefault("fin_api.grpc_client.retry.max_backoff", "1s")
+	v.SetDefault("fin_api.grpc_client.keepalive.time", "30s")
+	v.SetDefault("fin_api.grpc_client.keepalive.timeout", "10s")
+	v.SetDefault("fin_api.grpc_client.breaker.failure_threshold", 5)
+	v.SetDefault("fin_api.grpc_client.breaker.open_timeout", "30s")
+	v.SetDefault("fin_api.node_id", nodeIDUnset)
+	v.SetDefault("fin_api.replicas", 1)
+	v.SetDefault("fin_analytics.http_host", "0.0.0.0")
+	v.SetDefault("fin_analytics.http_port", 8081)
+	v.SetDefault("fin_analytics.grpc_host", "0.0.0.0")
+	v.SetDefault("fin_analytics.grpc_port", 9091)
+	v.SetDefault("postgres.sslmode", "disable")
+	v.SetDefault("postgres.auto_migrate", true)
+	v.SetDefault("postgres.replicas.max_lag", "5s")
+	v.SetDefault("postgres.replicas.lag_check_interval", "5s")
+	v.SetDefault("postgres.replicas.read_your_writes_window", "10s")
+	v.SetDefault("postgres.scatter_concurrency", 4)
+	v.SetDefault("postgres.pool.max_conns", 10)
+	v.SetDefault("postgres.pool.min_conns", 1)
+	v.SetDefault("postgres.pool.max_conn_lifetime", "1h")
+	v.SetDefault("postgres.pool.max_conn_idle_time", "30m")
+	v.SetDefault("postgres.pool.health_check_period", "1m")
+	v.SetDefault("postgres.pool.connect_timeout", "5s")
+	v.SetDefault("postgres.health.interval", "2s")
+	v.SetDefault("postgres.health.timeout", "1s")
+	v.SetDefault("postgres.health.failure_threshold", 3)
+	v.SetDefault("postgres.directory.enabled", false)
+	v.SetDefault("postgres.directory.refresh_interval", "1m")
+	v.SetDefault("analytics_db.conn_url", "postgres://localhost:5432/fintrack")
+	v.SetDefault("analytics_db.schema", "analytics")
+	v.SetDefault("analytics_db.sslmode", "disable")
+	v.SetDefault("analytics_db.auto_migrate", true)
+	v.SetDefault("analytics_db.pool.max_conns", 10)
+	v.SetDefault("analytics_db.pool.min_conns", 1)
+	v.SetDefault("analytics_db.pool.max_conn_lifetime", "1h")
+	v.SetDefault("analytics_db.pool.max_conn_idle_time", "30m")
+	v.SetDefault("analytics_db.pool.health_check_period", "1m")
+	v.SetDefault("analytics_db.pool.connect_timeout", "5s")
+	v.SetDefault("redis.host", "localhost")
+	v.SetDefault("redis.port", 6379)
+	v.SetDefault("redis.db", 0)
+	v.SetDefault("kafka.group_id", "fin-analytics-group")
+	v.SetDefault("cache.ttl", "15m")
+	v.SetDefault("cache.stale_ttl", "24h")
+	v.SetDefault("cache.local.max_entries", 10000)
+	v.SetDefault("cache.local.max_bytes", 64<<20)
+	v.SetDefault("cache.local.ttl", "1m")
+	v.SetDefault("rate_limit.requests_per_second", 0)
+	v.SetDefault("rate_limit.burst", 20)
+	v.SetDefault("features."+FeatureAdminAPI, true)
+	v.SetDefault("features."+FeatureStatsCache, true)
+	v.SetDefault("readiness.timeout", "2s")
+	v.SetDefault("shutdown.timeout", "30s")
+	v.SetDefault("shutdown.drain_delay", "5s")
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+	v.SetDefault("tls.enabled", false)
+	v.SetDefault("tls.cert_path", "")
+	v.SetDefault("tls.key_path", "")
+	v.SetDefault("tls.ca_path", "")
+	v.SetDefault("tls.client_auth", false)
+	v.SetDefault("tracing.exporter", "none")
+	v.SetDefault("tracing.endpoint", "localhost:4317")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	settings := v.AllSettings()
+	if err := resolveSecretFiles(settings, ""); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func decode(settings map[string]any, path string, service Service) (*Config, error) {
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("merge config: %w", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	cfg.Postgres.applySSLMode()
+	cfg.applyNodeID()
+	cfg.AnalyticsDB.ConnURL = withSSLMode(cfg.AnalyticsDB.ConnURL, cfg.AnalyticsDB.SSLMode)
+
+	if err := cfg.Validate(service); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,16 @@
+package config
+
+import "flag"
+
+// Flags are the command-line options every service accepts.
+type Flags struct {
+	Path        string
+	PrintConfig bool
+}
+
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.Path, "config", "config.yaml", "path to the config file")
+	fs.BoolVar(&f.PrintConfig, "print-config", false, "print the effective config with secrets redacted and exit")
+	return f
+}
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadable are the keys that can change without a restart. Everything
+// else is wired into clients, pools and listeners at startup.
+var reloadable = []string{"logging.level", "rate_limit", "cache.ttl", "cache.soft_ttl", "features"}
+
+// reloadDebounce coalesces the burst of events editors and Kubernetes
+// produce for a single save.
+const reloadDebounce = 200 * time.Millisecond
+
+// ErrRestartRequired is returned by Watcher.Reload when the new config
+// changes keys that are only read at startup.
+var ErrRestartRequired = errors.New("restart required")
+
+// Watcher keeps the current config and replaces it when the file changes
+// or the process receives SIGHUP. A reload is all or nothing: if any key
+// that can't be applied live changed, nothing is applied.
+type Watcher struct {
+	path     string
+	service  Service
+	current  atomic.Pointer[Config]
+	mu       sync.Mutex
+	onReload []func(*Config)
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewWatcher(path string, service Service, initial *Config) *Watcher {
+	w := &Watcher{path: path, service: service, stop: make(chan struct{})}
+	w.current.Store(initial)
+	return w
+}
+
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Enabled reports whether a feature toggle is on in the current config.
+func (w *Watcher) Enabled(feature string) bool {
+	return w.Current().Features.Enabled(feature)
+}
+
+// OnReload registers fn to be called with every applied config. Register
+// callbacks before Run.
+func (w *Watcher) OnReload(fn func(*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onReload = append(w.onReload, fn)
+}
+
+// Reload reads and validates the config file and applies it if only
+// reloadable keys changed. It returns the keys that changed.
+func (w *Watcher) Reload() ([]string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next, err := Load(w.path, w.service)
+	if err != nil {
+		return nil, err
+	}
+
+	current := w.Current()
+	var changed, rejected []string
+	for _, key := range changedKeys(reflect.ValueOf(*current), reflect.ValueOf(*next), "") {
+		if !w.inService(key) {
+			continue
+		}
+		changed = append(changed, key)
+		if !isReloadable(key) {
+			rejected = append(rejected, key)
+		}
+	}
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("%w to change %s", ErrRestartRequired, strings.Join(rejected, ", "))
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	w.current.Store(next)
+	for _, fn := range w.onReload {
+		fn(next)
+	}
+	return changed, nil
+}
+
+// Run reloads the config on SIGHUP and on changes to the file until Stop
+// is called. The directory is watched rather than the file so that
+// editors that save via rename and Kubernetes ConfigMap symlink swaps are
+// both noticed. If the directory can't be watched, SIGHUP still works.
+func (w *Watcher) Run() error {
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+	fsw, err := w.watchDir()
+	if err != nil {
+		slog.Warn("Config file watching disabled, reload with SIGHUP", "path", w.path, "error", err)
+	} else {
+		defer fsw.Close()
+		events, errs = fsw.Events, fsw.Errors
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-w.stop:
+			return nil
+		case <-hup:
+			w.reload("SIGHUP")
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if w.affects(event) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			slog.Warn("Config watcher error", "error", err)
+		case <-debounce:
+			debounce = nil
+			w.reload("file change")
+		}
+	}
+}
+
+func (w *Watcher) watchDir() (*fsnotify.Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create config watcher: %w", err)
+	}
+	if err := fsw.Add(filepath.Dir(w.path)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("watch %s: %w", filepath.Dir(w.path), err)
+	}
+	return fsw, nil
+}
+
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+func (w *Watcher) reload(trigger string) {
+	changed, err := w.Reload()
+	if err != nil {
+		slog.Error("Config reload rejected", "trigger", trigger, "path", w.path, "error", err)
+		return
+	}
+	if len(changed) > 0 {
+		slog.Info("Config reloaded", "trigger", trigger, "path", w.path, "changed", changed)
+	}
+}
+
+func (w *Watcher) affects(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	return filepath.Clean(event.Name) == filepath.Clean(w.path) || filepath.Base(event.Name) == "..data"
+}
+
+func (w *Watcher) inService(key string) bool {
+	section, _, _ := strings.Cut(key, ".")
+	for _, s := range serviceSections[w.service] {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+func isReloadable(key string) bool {
+	for _, prefix := range reloadable {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedKeys lists the config keys, named as in the file, whose values
+// differ between a and b. Lists and maps are compared as a whole.
+func changedKeys(a, b reflect.Value, prefix string) []string {
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var keys []string
+	for i := 0; i < a.NumField(); i++ {
+		key := a.Type().Field(i).Tag.Get("mapstructure")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		keys = append(keys, changedKeys(a.Field(i), b.Field(i), key)...)
+	}
+	return keys
+}
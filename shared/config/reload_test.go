@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const analyticsConfig = `
+redis:
+  host: redis
+kafka:
+  brokers: [kafka:9092]
+logging:
+  level: info
+cache:
+  ttl: 15m
+`
+
+func newWatcher(t *testing.T, content string) (*Watcher, string) {
+	t.Helper()
+	path := writeFile(t, "config.yaml", content)
+	cfg, err := Load(path, FinAnalytics)
+	require.NoError(t, err)
+	return NewWatcher(path, FinAnalytics, cfg), path
+}
+
+func rewrite(t *testing.T, path, old, new string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(content), old, new, 1)), 0o600))
+}
+
+func TestReloadAppliesReloadableChanges(t *testing.T) {
+	w, path := newWatcher(t, analyticsConfig)
+	var applied *Config
+	w.OnReload(func(cfg *Config) { applied = cfg })
+
+	rewrite(t, path, "ttl: 15m", "ttl: 5m")
+	rewrite(t, path, "level: info", "level: debug")
+	changed, err := w.Reload()
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"cache.ttl", "logging.level"}, changed)
+	require.NotNil(t, applied)
+	assert.Equal(t, 5*time.Minute, w.Current().Cache.TTL)
+	assert.Equal(t, "debug", w.Current().Logging.Level)
+}
+
+func TestReloadRejectsStructuralChanges(t *testing.T) {
+	w, path := newWatcher(t, analyticsConfig)
+	w.OnReload(func(*Config) { t.Fatal("rejected config must not be applied") })
+
+	rewrite(t, path, "host: redis", "host: redis-2")
+	rewrite(t, path, "ttl: 15m", "ttl: 5m")
+	_, err := w.Reload()
+
+	require.ErrorIs(t, err, ErrRestartRequired)
+	assert.ErrorContains(t, err, "redis.host")
+	assert.NotContains(t, err.Error(), "cache.ttl")
+	assert.Equal(t, 15*time.Minute, w.Current().Cache.TTL)
+}
+
+func TestReloadKeepsConfigWhenInvalid(t *testing.T) {
+	w, path := newWatcher(t, analyticsConfig)
+
+	rewrite(t, path, "level: info", "level: loud")
+	_, err := w.Reload()
+
+	assert.ErrorContains(t, err, "logging.level")
+	assert.Equal(t, "info", w.Current().Logging.Level)
+}
+
+func TestRunReloadsOnFileChange(t *testing.T) {
+	w, path := newWatcher(t, analyticsConfig)
+	reloaded := make(chan *Config, 1)
+	w.OnReload(func(cfg *Config) { reloaded <- cfg })
+
+	done := make(chan error, 1)
+	go func() { done <- w.Run() }()
+	t.Cleanup(func() {
+		w.Stop()
+		require.NoError(t, <-done)
+	})
+
+	// Give the watcher time to subscribe before writing.
+	time.Sleep(50 * time.Millisecond)
+	rewrite(t, path, "level: info", "level: warn")
+
+	select {
+	case cfg := <-reloaded:
+		assert.Equal(t, "warn", cfg.Logging.Level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+}
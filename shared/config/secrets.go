@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const secretFileSuffix = "_file"
+
+// secretKeys are redacted by --print-config wherever they appear.
+var secretKeys = map[string]bool{
+	"conn_url":     true,
+	"replica_urls": true,
+	"key":          true,
+	"password":     true,
+}
+
+// resolveSecretFiles replaces every "<key>_file: <path>" with "<key>: <file
+// contents>", so that passwords and API keys can be mounted as Docker or
+// Kubernetes secrets instead of being written into the config file. prefix
+// is the key path of settings, used in errors.
+func resolveSecretFiles(settings map[string]any, prefix string) error {
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			if err := resolveSecretFiles(value, path); err != nil {
+				return err
+			}
+		case []any:
+			for i, item := range value {
+				if item, ok := item.(map[string]any); ok {
+					if err := resolveSecretFiles(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+						return err
+					}
+				}
+			}
+		case string:
+			if !strings.HasSuffix(key, secretFileSuffix) || value == "" {
+				continue
+			}
+			content, err := os.ReadFile(value)
+			if err != nil {
+				return fmt.Errorf("%s: read secret: %w", path, err)
+			}
+			settings[strings.TrimSuffix(key, secretFileSuffix)] = strings.TrimRight(string(content), "\r\n")
+			delete(settings, key)
+		}
+	}
+	return nil
+}
+
+// redact returns a copy of value with the secrets masked. Connection URLs
+// keep everything but the password so the printed config still shows
+// where the service connects.
+func redact(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(value))
+		for key, item := range value {
+			if secretKeys[key] {
+				out[key] = redactSecret(item)
+			} else {
+				out[key] = redact(item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			out[i] = redact(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func redactSecret(value any) any {
+	switch value := value.(type) {
+	case string:
+		if value == "" {
+			return value
+		}
+		if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+			return u.Redacted()
+		}
+		return "REDACTED"
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			out[i] = redactSecret(item)
+		}
+		return out
+	case []string:
+		out := make([]any, len(value))
+		for i, item := range value {
+			out[i] = redactSecret(item)
+		}
+		return out
+	default:
+		return "REDACTED"
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// maxNodeID mirrors idgen.MaxNodes in fin-api: node IDs are stored in four
+// bits of every transaction ID, so at most maxNodeID+1 instances can run.
+const maxNodeID = 15
+
+var (
+	logLevels       = []string{"debug", "info", "warn", "error"}
+	logFormats      = []string{"json", "text"}
+	traceExporters  = []string{"none", "otlp", "stdout", "memory"}
+	postgresSSLMode = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	knownFeatures   = []string{FeatureAdminAPI, FeatureStatsCache}
+	// schemaName is what may be templated into SQL as a schema name.
+	schemaName = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+)
+
+type fieldError struct {
+	key string
+	msg string
+}
+
+func (e fieldError) Error() string {
+	return e.key + ": " + e.msg
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fieldError{key: key, msg: fmt.Sprintf(format, args...)})
+	}
+}
+
+func (v *validator) port(key string, port int) {
+	v.check(port > 0 && port <= 65535, key, "must be a port between 1 and 65535, got %d", port)
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, key, "must be one of %v, got %q", allowed, value)
+}
+
+func (v *validator) hostPort(key, value string) {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		v.check(false, key, "must be host:port, got %q", value)
+		return
+	}
+	n, err := strconv.Atoi(port)
+	v.check(host != "" && err == nil && n > 0 && n <= 65535, key, "must be host:port, got %q", value)
+}
+
+func (v *validator) connURL(key, value string) {
+	if value == "" {
+		v.check(false, key, "must not be empty")
+		return
+	}
+	// Keyword/value connection strings are left to pgx.
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" {
+		v.check(u.Scheme == "postgres" || u.Scheme == "postgresql", key, "must be a postgres:// URL, got scheme %q", u.Scheme)
+		v.check(u.Host != "", key, "must include a host")
+	}
+}
+
+// Validate reports every problem in the sections service uses, one error
+// per field, so that a broken deployment fails at startup with all of them
+// rather than later with the first.
+func (c *Config) Validate(service Service) error {
+	v := &validator{}
+
+	v.check(c.App.KafkaTopic != "", "app.kafka_topic", "must not be empty")
+	v.check(len(c.Kafka.Brokers) > 0, "kafka.brokers", "must list at least one broker")
+	for i, broker := range c.Kafka.Brokers {
+		v.hostPort(fmt.Sprintf("kafka.brokers[%d]", i), broker)
+	}
+	v.oneOf("logging.level", c.Logging.Level, logLevels)
+	v.oneOf("logging.format", c.Logging.Format, logFormats)
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, traceExporters)
+	if c.Tracing.Exporter == "otlp" {
+		v.check(c.Tracing.Endpoint != "", "tracing.endpoint", "must be set for the otlp exporter")
+	}
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	v.check(c.Readiness.Timeout > 0, "readiness.timeout", "must be positive")
+	v.check(c.Shutdown.Timeout > 0, "shutdown.timeout", "must be positive")
+	v.check(c.Shutdown.DrainDelay >= 0, "shutdown.drain_delay", "must not be negative, got %s", c.Shutdown.DrainDelay)
+	v.check(c.Shutdown.DrainDelay < c.Shutdown.Timeout, "shutdown.drain_delay", "must be shorter than shutdown.timeout")
+	for name := range c.Features {
+		v.oneOf("features."+name, name, knownFeatures)
+	}
+	if c.TLS.Enabled {
+		v.check(c.TLS.CertPath != "", "tls.cert_path", "must be set when tls is enabled")
+		v.check(c.TLS.KeyPath != "", "tls.key_path", "must be set when tls is enabled")
+		// fin-analytics verifies fin-api with the CA even without client_auth.
+		v.check(c.TLS.CAPath != "" || (service == FinAPI && !c.TLS.ClientAuth), "tls.ca_path", "must be set for client_auth and in fin-analytics")
+	}
+
+	switch service {
+	case FinAPI:
+		c.validateFinAPI(v)
+	case FinAnalytics:
+		c.validateFinAnalytics(v)
+	default:
+		return fmt.Errorf("unknown service %q", service)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+func (c *Config) validateFinAPI(v *validator) {
+	v.port("fin_api.http_port", c.FinAPI.HTTPPort)
+	v.port("fin_api.grpc_port", c.FinAPI.GRPCPort)
+	v.check(c.FinAPI.HTTPPort != c.FinAPI.GRPCPort, "fin_api.grpc_port", "must differ from fin_api.http_port")
+	v.check(c.FinAPI.Replicas > 0 && c.FinAPI.Replicas <= maxNodeID+1, "fin_api.replicas", "must be between 1 and %d, one node_id each, got %d", maxNodeID+1, c.FinAPI.Replicas)
+	if c.FinAPI.NodeID == nodeIDUnset {
+		v.check(false, "fin_api.node_id", "must be set to a unique id per instance when fin_api.replicas is %d", c.FinAPI.Replicas)
+	} else {
+		v.check(c.FinAPI.NodeID >= 0 && c.FinAPI.NodeID < max(c.FinAPI.Replicas, 1), "fin_api.node_id", "must be between 0 and fin_api.replicas-1, got %d", c.FinAPI.NodeID)
+	}
+
+	pg := c.Postgres
+	v.oneOf("postgres.sslmode", pg.SSLMode, postgresSSLMode)
+	v.check(len(pg.Shards) > 0, "postgres.shards", "must list at least one shard")
+	names := make(map[string]bool, len(pg.Shards))
+	for i, shard := range pg.Shards {
+		key := fmt.Sprintf("postgres.shards[%d]", i)
+		v.check(shard.Name != "", key+".name", "must not be empty")
+		v.check(shard.Name == "" || !names[shard.Name], key+".name", "duplicate shard name %q", shard.Name)
+		names[shard.Name] = true
+		v.connURL(key+".conn_url", shard.ConnURL)
+		for j, replica := range shard.ReplicaURLs {
+			v.connURL(fmt.Sprintf("%s.replica_urls[%d]", key, j), replica)
+		}
+		v.check(shard.Buckets >= 0, key+".buckets", "must not be negative, got %d", shard.Buckets)
+		if shard.Pool != (PoolConfig{}) {
+			validatePool(v, key+".pool", shard.Pool.WithDefaults(pg.Pool))
+		}
+	}
+	validatePool(v, "postgres.pool", pg.Pool)
+	if pg.Directory.Enabled {
+		v.connURL("postgres.directory.conn_url", pg.Directory.ConnURL)
+		v.check(pg.Directory.RefreshInterval > 0, "postgres.directory.refresh_interval", "must be positive")
+	}
+	v.check(pg.ScatterConcurrency > 0, "postgres.scatter_concurrency", "must be positive, got %d", pg.ScatterConcurrency)
+	v.check(pg.Health.Interval > 0, "postgres.health.interval", "must be positive")
+	v.check(pg.Health.FailureThreshold > 0, "postgres.health.failure_threshold", "must be positive, got %d", pg.Health.FailureThreshold)
+
+	v.check(c.RateLimit.RequestsPerSecond >= 0, "rate_limit.requests_per_second", "must not be negative, got %g", c.RateLimit.RequestsPerSecond)
+	if c.RateLimit.RequestsPerSecond > 0 {
+		v.check(c.RateLimit.Burst > 0, "rate_limit.burst", "must be positive when the limit is enabled, got %d", c.RateLimit.Burst)
+	}
+
+	c.validateAuth(v)
+}
+
+func (c *Config) validateAuth(v *validator) {
+	for i, key := range c.Auth.APIKeys {
+		v.check(key.Name != "", fmt.Sprintf("auth.api_keys[%d].name", i), "must not be empty")
+	}
+}
+
+func validatePool(v *validator, key string, pool PoolConfig) {
+	v.check(pool.MaxConns > 0, key+".max_conns", "must be positive, got %d", pool.MaxConns)
+	v.check(pool.MinConns >= 0 && pool.MinConns <= pool.MaxConns, key+".min_conns", "must be between 0 and max_conns (%d), got %d", pool.MaxConns, pool.MinConns)
+}
+
+func (c *Config) validateFinAnalytics(v *validator) {
+	v.port("fin_analytics.http_port", c.FinAnalytics.HTTPPort)
+	v.port("fin_analytics.grpc_port", c.FinAnalytics.GRPCPort)
+	v.check(c.FinAnalytics.HTTPPort != c.FinAnalytics.GRPCPort, "fin_analytics.grpc_port", "must differ from fin_analytics.http_port")
+	if len(c.FinAPI.GRPCEndpoints) == 0 {
+		v.check(c.FinAPI.GRPCTarget != "", "fin_api.grpc_target", "must not be empty")
+	}
+	for i, endpoint := range c.FinAPI.GRPCEndpoints {
+		v.hostPort(fmt.Sprintf("fin_api.grpc_endpoints[%d]", i), endpoint)
+	}
+	c.validateGRPCClient(v)
+	v.connURL("analytics_db.conn_url", c.AnalyticsDB.ConnURL)
+	v.check(schemaName.MatchString(c.AnalyticsDB.Schema), "analytics_db.schema", "must be a lowercase identifier, got %q", c.AnalyticsDB.Schema)
+	v.oneOf("analytics_db.sslmode", c.AnalyticsDB.SSLMode, postgresSSLMode)
+	validatePool(v, "analytics_db.pool", c.AnalyticsDB.Pool)
+	v.check(c.Redis.Host != "", "redis.host", "must not be empty")
+	v.port("redis.port", c.Redis.Port)
+	v.check(c.Redis.DB >= 0, "redis.db", "must not be negative, got %d", c.Redis.DB)
+	v.check(c.Kafka.GroupID != "", "kafka.group_id", "must not be empty")
+	v.check(c.Cache.TTL > 0, "cache.ttl", "must be positive")
+	v.check(c.Cache.SoftTTL >= 0, "cache.soft_ttl", "must not be negative")
+	v.check(c.Cache.SoftTTL <= c.Cache.TTL, "cache.soft_ttl", "must not be longer than cache.ttl")
+	v.check(c.Cache.StaleTTL >= c.Cache.TTL, "cache.stale_ttl", "must not be shorter than cache.ttl")
+	if c.Cache.Local.MaxEntries != 0 {
+		v.check(c.Cache.Local.MaxEntries > 0, "cache.local.max_entries", "must not be negative")
+		v.check(c.Cache.Local.MaxBytes > 0, "cache.local.max_bytes", "must be positive")
+		v.check(c.Cache.Local.TTL > 0, "cache.local.ttl", "must be positive")
+	}
+	c.validateAuth(v)
+}
+
+func (c *Config) validateGRPCClient(v *validator) {
+	client := c.FinAPI.GRPCClient
+	v.check(client.Timeout > 0, "fin_api.grpc_client.timeout", "must be positive")
+	v.oneOf("fin_api.grpc_client.load_balancing", client.LoadBalancing, []string{"round_robin", "pick_first"})
+	v.check(client.Retry.MaxAttempts >= 1 && client.Retry.MaxAttempts <= 5, "fin_api.grpc_client.retry.max_attempts", "must be between 1 and 5, got %d", client.Retry.MaxAttempts)
+	v.check(client.Retry.InitialBackoff > 0, "fin_api.grpc_client.retry.initial_backoff", "must be positive")
+	v.check(client.Retry.MaxBackoff >= client.Retry.InitialBackoff, "fin_api.grpc_client.retry.max_backoff", "must not be shorter than initial_backoff")
+	v.check(client.Keepalive.Time >= 10*time.Second, "fin_api.grpc_client.keepalive.time", "must be at least 10s, got %s", client.Keepalive.Time)
+	v.check(client.Keepalive.Timeout > 0, "fin_api.grpc_client.keepalive.timeout", "must be positive")
+	v.check(client.Breaker.FailureThreshold > 0, "fin_api.grpc_client.breaker.failure_threshold", "must be positive, got %d", client.Breaker.FailureThreshold)
+	v.check(client.Breaker.OpenTimeout > 0, "fin_api.grpc_client.breaker.open_timeout", "must be positive")
+}
@@ -0,0 +1,220 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultTimeout = 2 * time.Second
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusFail     Status = "fail"
+)
+
+// CheckFunc probes a dependency. details, when non-nil, is included in the
+// report as is.
+type CheckFunc func(ctx context.Context) (details any, err error)
+
+type degradedError struct {
+	err error
+}
+
+func (e degradedError) Error() string { return e.err.Error() }
+func (e degradedError) Unwrap() error { return e.err }
+
+// Degraded marks a check failure that should not take the instance out of
+// rotation, such as one of several shards or an optional dependency
+// being unavailable.
+func Degraded(err error) error {
+	return degradedError{err: err}
+}
+
+type Result struct {
+	Name       string  `json:"name"`
+	Status     Status  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+	Details    any     `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs registered checks in parallel, each bounded by timeout.
+type Checker struct {
+	timeout  time.Duration
+	checks   []check
+	draining atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Simple adapts a check that only reports an error.
+func Simple(fn func(ctx context.Context) error) CheckFunc {
+	return func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	}
+}
+
+// Optional reports the failures of fn as degraded. It is for dependencies
+// the instance can serve without, whose outage would otherwise take every
+// replica out of rotation at once.
+func Optional(fn CheckFunc) CheckFunc {
+	return func(ctx context.Context) (any, error) {
+		details, err := fn(ctx)
+		if err != nil {
+			return details, Degraded(err)
+		}
+		return details, nil
+	}
+}
+
+// Drain makes every following report fail without running the checks, so
+// load balancers stop routing to the instance while it shuts down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// DrainFor drains the checker and waits delay, or until ctx is done, so
+// that probes and load balancers see the instance as not ready before its
+// listeners close.
+func (c *Checker) DrainFor(ctx context.Context, delay time.Duration) {
+	c.Drain()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// Run executes every check and aggregates the results. The report fails if
+// any check fails, and is degraded if any check is degraded.
+func (c *Checker) Run(ctx context.Context) Report {
+	if c.draining.Load() {
+		return Report{
+			Status: StatusFail,
+			Checks: []Result{{Name: "shutdown", Status: StatusFail, Error: "shutting down"}},
+		}
+	}
+
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, result := range results {
+		switch result.Status {
+		case StatusFail:
+			report.Status = StatusFail
+		case StatusDegraded:
+			if report.Status == StatusOK {
+				report.Status = StatusDegraded
+			}
+		}
+	}
+	return report
+}
+
+type outcome struct {
+	details any
+	err     error
+}
+
+// run enforces the timeout even for checks that ignore ctx, such as client
+// libraries without context support; a check that overruns is reported as
+// failed and left to finish in the background.
+func (c *Checker) run(ctx context.Context, chk check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := chk.fn(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = ctx.Err()
+	}
+
+	result := Result{
+		Name:       chk.name,
+		Status:     StatusOK,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		Details:    out.details,
+	}
+	if out.err != nil {
+		result.Error = out.err.Error()
+		result.Status = StatusFail
+		var degraded degradedError
+		if errors.As(out.err, &degraded) {
+			result.Status = StatusDegraded
+		}
+	}
+	return result
+}
+
+// Handler serves the report as JSON: 200 while the status is ok or
+// degraded, 503 once it fails.
+func (c *Checker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Run(r.Context())
+
+		code := http.StatusOK
+		if report.Status == StatusFail {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// LivenessHandler reports that the process is able to serve requests. It
+// deliberately checks no dependencies: a dead database should take the pod
+// out of rotation via readiness, not restart it.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error { return nil }
+
+func TestRunAggregatesStatuses(t *testing.T) {
+	tests := []struct {
+		name   string
+		errs   []error
+		status Status
+	}{
+		{name: "all ok", errs: []error{nil, nil}, status: StatusOK},
+		{name: "degraded", errs: []error{nil, Degraded(errors.New("shard1 down"))}, status: StatusDegraded},
+		{name: "failed wins", errs: []error{Degraded(errors.New("shard1 down")), errors.New("kafka down")}, status: StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			for _, err := range tt.errs {
+				err := err
+				checker.Register("dep", Simple(func(context.Context) error { return err }))
+			}
+
+			assert.Equal(t, tt.status, checker.Run(context.Background()).Status)
+		})
+	}
+}
+
+func TestOptionalDegrades(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("redis", Simple(ok))
+	checker.Register("fin-api", Optional(Simple(func(context.Context) error { return errors.New("unavailable") })))
+
+	report := checker.Run(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, "unavailable", report.Checks[1].Error)
+}
+
+func TestRunTimesOutChecksThatIgnoreContext(t *testing.T) {
+	checker := NewChecker(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	checker.Register("stuck", Simple(func(context.Context) error {
+		<-release
+		return nil
+	}))
+	checker.Register("fast", Simple(ok))
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, StatusFail, report.Checks[0].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+	assert.Equal(t, StatusOK, report.Checks[1].Status)
+}
+
+func TestDrainFailsReadiness(t *testing.T) {
+	called := false
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", Simple(func(context.Context) error {
+		called = true
+		return nil
+	}))
+	checker.Drain()
+
+	report := checker.Run(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, "shutdown", report.Checks[0].Name)
+	assert.False(t, called)
+}
+
+func TestDrainForWaitsBeforeReturning(t *testing.T) {
+	checker := NewChecker(time.Second)
+	start := time.Now()
+	checker.DrainFor(context.Background(), 50*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, StatusFail, checker.Run(context.Background()).Status)
+
+	// The shutdown deadline cuts the delay short.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	NewChecker(time.Second).DrainFor(ctx, time.Hour)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestHandler(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", func(context.Context) (any, error) {
+		return map[string]int{"shards": 2}, nil
+	})
+	checker.Register("kafka", Simple(func(context.Context) error { return errors.New("no brokers") }))
+
+	rec := httptest.NewRecorder()
+	checker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "postgres", report.Checks[0].Name)
+	assert.Equal(t, map[string]any{"shards": float64(2)}, report.Checks[0].Details)
+	assert.Equal(t, "no brokers", report.Checks[1].Error)
+}
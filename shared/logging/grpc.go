@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type userRequest interface {
+	GetUserId() int64
+}
+
+// UnaryServerInterceptor stores a request logger with the method (and user
+// ID, for requests that carry one) in the context and logs each call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = With(ctx, "grpc_method", info.FullMethod)
+		if r, ok := req.(userRequest); ok {
+			ctx = With(ctx, "user_id", r.GetUserId())
+		}
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, err, start)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs each stream once it ends. The user ID is
+// not known until the handler receives the request, so only the method is
+// added to the stream context.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := With(ss.Context(), "grpc_method", info.FullMethod)
+
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, err, start)
+		return err
+	}
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func logCall(ctx context.Context, err error, start time.Time) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.Canceled,
+		codes.Unauthenticated, codes.PermissionDenied:
+	default:
+		level = slog.LevelError
+	}
+	attrs := []any{"code", code.String(), "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	FromContext(ctx).Log(ctx, level, "gRPC request", attrs...)
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// HTTPMiddleware replaces chi's text request logger. It stores a request
+// logger carrying the request ID in the context and writes one access log
+// entry per request. It must run after middleware.RequestID and the
+// tracing middleware.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := With(r.Context(), "request_id", middleware.GetReqID(r.Context()))
+		r = r.WithContext(ctx)
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"duration", time.Since(start),
+		}
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				attrs = append(attrs, "route", pattern)
+			}
+			if userID, err := strconv.Atoi(rctx.URLParam("userID")); err == nil {
+				attrs = append(attrs, "user_id", userID)
+			}
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		FromContext(ctx).Log(ctx, level, "HTTP request", attrs...)
+	})
+}
+
+// UserIDFromURL adds the {userID} URL parameter to the request logger.
+// Mount it on routers whose pattern contains {userID}.
+func UserIDFromURL(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID, err := strconv.Atoi(chi.URLParam(r, "userID")); err == nil {
+			r = r.WithContext(With(r.Context(), "user_id", userID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"fin-shared/config"
+)
+
+// level is shared by every logger New builds so that SetLevel takes
+// effect without rebuilding them.
+var level = new(slog.LevelVar)
+
+// New builds the process logger. Format is json (default) or text; level
+// is one of debug, info, warn or error and can be changed later with
+// SetLevel.
+func New(cfg config.LoggingConfig, w io.Writer) (*slog.Logger, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(cfg.Format) {
+	case "", "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+}
+
+// SetLevel changes the level of the loggers built by New.
+func SetLevel(name string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("parse log level: %w", err)
+	}
+	level.Set(l)
+	return nil
+}
+
+type loggerKey struct{}
+
+// WithContext stores logger in ctx for FromContext.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// With returns a context whose logger carries the extra fields, so that
+// every layer below logs with the same correlation fields. Call sites
+// don't repeat them: slog would write the key twice.
+func With(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, logger(ctx).With(args...))
+}
+
+// FromContext returns the request logger stored in ctx, or slog.Default.
+// The current trace and span IDs are added when ctx carries a span.
+func FromContext(ctx context.Context) *slog.Logger {
+	l := logger(ctx)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = l.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	return l
+}
+
+func logger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// Fatal logs msg at error level and exits.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
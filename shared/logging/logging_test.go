@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+
+	fintrackv1 "fin-shared/api/fintrack/v1"
+	"fin-shared/config"
+)
+
+func newTestLogger(t *testing.T) (*slog.Logger, *bytes.Buffer) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger, err := New(config.LoggingConfig{Level: "debug", Format: "json"}, &buf)
+	require.NoError(t, err)
+	return logger, &buf
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]any
+		require.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	_, err := New(config.LoggingConfig{Level: "verbose"}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = New(config.LoggingConfig{Level: "info", Format: "xml"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestNewFiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(config.LoggingConfig{Level: "warn"}, &buf)
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	entries := decodeLines(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0]["msg"])
+}
+
+func TestSetLevelAppliesToExistingLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(config.LoggingConfig{Level: "info"}, &buf)
+	require.NoError(t, err)
+
+	logger.Debug("dropped")
+	require.NoError(t, SetLevel("debug"))
+	logger.Debug("kept")
+	assert.Error(t, SetLevel("verbose"))
+
+	entries := decodeLines(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0]["msg"])
+}
+
+func TestFromContextAddsFieldsAndTraceID(t *testing.T) {
+	logger, buf := newTestLogger(t)
+
+	ctx := WithContext(context.Background(), logger)
+	ctx = With(ctx, "user_id", 42)
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "op")
+	defer span.End()
+
+	FromContext(ctx).Info("hello")
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, float64(42), entries[0]["user_id"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), entries[0]["trace_id"])
+}
+
+func TestHTTPMiddlewareCorrelatesRequest(t *testing.T) {
+	logger, buf := newTestLogger(t)
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), logger)))
+		})
+	})
+	router.Use(HTTPMiddleware)
+	router.Route("/v1/users/{userID}", func(r chi.Router) {
+		r.Use(UserIDFromURL)
+		r.Get("/transactions", func(w http.ResponseWriter, r *http.Request) {
+			FromContext(r.Context()).Info("listing")
+			w.WriteHeader(http.StatusTeapot)
+		})
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/7/transactions", nil))
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 2)
+
+	handler, access := entries[0], entries[1]
+	assert.Equal(t, "listing", handler["msg"])
+	assert.Equal(t, float64(7), handler["user_id"])
+	assert.NotEmpty(t, handler["request_id"])
+
+	assert.Equal(t, "HTTP request", access["msg"])
+	assert.Equal(t, handler["request_id"], access["request_id"])
+	assert.Equal(t, "/v1/users/{userID}/transactions", access["route"])
+	assert.Equal(t, float64(http.StatusTeapot), access["status"])
+	assert.Equal(t, float64(7), access["user_id"])
+}
+
+func TestUnaryServerInterceptorAddsUserID(t *testing.T) {
+	logger, buf := newTestLogger(t)
+	ctx := WithContext(context.Background(), logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/fintrack.TransactionService/GetUserTransactions"}
+
+	_, err := UnaryServerInterceptor()(ctx, &fintrackv1.UserRequest{UserId: 9}, info, func(ctx context.Context, req any) (any, error) {
+		FromContext(ctx).Info("handling")
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, float64(9), entries[0]["user_id"])
+	assert.Equal(t, info.FullMethod, entries[1]["grpc_method"])
+	assert.Equal(t, "OK", entries[1]["code"])
+}
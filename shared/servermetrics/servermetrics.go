@@ -0,0 +1,93 @@
+// Package servermetrics records the latency of served HTTP and gRPC
+// requests. Both services register the same histograms under their own
+// namespace, so dashboards can query them the same way.
+package servermetrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Server holds the request histograms. Its methods are safe to call on a
+// nil *Server and then record nothing.
+type Server struct {
+	httpRequests *prometheus.HistogramVec
+	grpcRequests *prometheus.HistogramVec
+}
+
+// New creates the histograms under namespace and registers them with reg.
+func New(namespace string, reg prometheus.Registerer) *Server {
+	s := &Server{
+		httpRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		grpcRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_server_handling_seconds",
+			Help:      "Served gRPC call latency by method and status code; streams are timed until they end.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+	reg.MustRegister(s.httpRequests, s.grpcRequests)
+	return s
+}
+
+// HTTPMiddleware records request latency labelled with the chi route
+// pattern, so /v1/users/1/stats and /v1/users/2/stats share a series. It
+// must be mounted on a chi router.
+func (s *Server) HTTPMiddleware(next http.Handler) http.Handler {
+	if s == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		code := ww.Status()
+		if code == 0 {
+			code = http.StatusOK
+		}
+		s.httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+	})
+}
+
+func (s *Server) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		s.observeGRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func (s *Server) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		s.observeGRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (s *Server) observeGRPC(method string, start time.Time, err error) {
+	if s == nil {
+		return
+	}
+	s.grpcRequests.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
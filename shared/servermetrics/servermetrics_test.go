@@ -0,0 +1,70 @@
+package servermetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPMiddlewareUsesRoutePattern(t *testing.T) {
+	s := New("fintrack", prometheus.NewRegistry())
+	router := chi.NewRouter()
+	router.Use(s.HTTPMiddleware)
+	router.Get("/v1/users/{userID}/stats", func(w http.ResponseWriter, r *http.Request) {})
+	router.Post("/v1/users/{userID}/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/1/stats", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/2/stats", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/users/2/transactions", nil))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(s.httpRequests))
+	assert.Equal(t, uint64(2), sampleCount(t, s.httpRequests, "GET", "/v1/users/{userID}/stats", "200"))
+	assert.Equal(t, uint64(1), sampleCount(t, s.httpRequests, "POST", "/v1/users/{userID}/transactions", "201"))
+}
+
+func TestServerInterceptorsRecordCode(t *testing.T) {
+	s := New("fintrack", prometheus.NewRegistry())
+	unary := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad interval")
+	}
+	stream := func(srv any, ss grpc.ServerStream) error { return nil }
+
+	_, err := s.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/fintrack.v1.AnalyticsService/GetTimeSeries"}, unary)
+	assert.Error(t, err)
+	assert.NoError(t, s.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/fintrack.v1.AnalyticsService/WatchStats"}, stream))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(s.grpcRequests))
+	assert.Equal(t, uint64(1), sampleCount(t, s.grpcRequests, "/fintrack.v1.AnalyticsService/GetTimeSeries", "InvalidArgument"))
+	assert.Equal(t, uint64(1), sampleCount(t, s.grpcRequests, "/fintrack.v1.AnalyticsService/WatchStats", "OK"))
+}
+
+func TestNilServer(t *testing.T) {
+	var s *Server
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	s.HTTPMiddleware(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	unary := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	resp, err := s.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/m"}, unary)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func sampleCount(t *testing.T, histogram *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, histogram.WithLabelValues(labels...).(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
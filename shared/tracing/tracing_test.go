@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fin-shared/config"
+)
+
+func TestHTTPMiddlewareNamesSpanAfterRoute(t *testing.T) {
+	provider, err := New(context.Background(), config.TracingConfig{Exporter: ExporterMemory, SampleRatio: 1}, "fin-api")
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	router := chi.NewRouter()
+	router.Use(HTTPMiddleware)
+	router.Get("/v1/users/{userID}/transactions", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/7/transactions", nil))
+
+	spans := provider.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /v1/users/{userID}/transactions", spans[0].Name)
+}
+
+func TestNewRejectsUnknownExporter(t *testing.T) {
+	_, err := New(context.Background(), config.TracingConfig{Exporter: "zipkin"}, "fin-api")
+	assert.Error(t, err)
+}